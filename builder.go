@@ -3,9 +3,10 @@ package sqlbuilder
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
-	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,22 @@ import (
 	"github.com/suryaherdiyanto/sqlbuilder/pkg"
 )
 
+var ErrColumnMismatch = errors.New("sqlbuilder: rows do not have the same columns")
+var ErrNoColumns = errors.New("sqlbuilder: no columns to write")
+var ErrReplaceUnsupported = errors.New("sqlbuilder: REPLACE is not supported by this dialect, use Upsert")
+
+// statementKind tells the statement rendered by the builder apart, to place the global scope conditions.
+type statementKind int
+
+const (
+	// selectKind scopes the table and its joins, through the where and on clauses.
+	selectKind statementKind = iota
+	// mutationKind is an UPDATE or DELETE, whose joins carry no scope conditions.
+	mutationKind
+	// insertKind writes the scoped columns into its rows and binds no scope condition.
+	insertKind
+)
+
 type SQLBuilder struct {
 	Dialect              clause.SQLDialector
 	sql                  *sql.DB
@@ -23,12 +40,43 @@ type SQLBuilder struct {
 	enableLogging        bool
 	logger               queryLogger
 	tempTable            string
+	table                string
+	softDeletes          map[string]string
+	trashed              trashedMode
+	globalScopes         []globalScope
+	withoutScopes        []string
+	withoutAllScopes     bool
+	nested               bool
+	placeholderOffset    int
+	leadingValues        int
+	timestampTables      []string
+	createdAtColumn      string
+	updatedAtColumn      string
+	clock                func() time.Time
+	utc                  bool
+	withoutTimestamps    bool
+	fullTableWrites      bool
+	allowFullTable       bool
+	strictScan           bool
+	mapScanMode          MapScanMode
+	unmappedColumns      []string
+	primaryKey           string
+	returning            []string
 	rawStatement         string
+	statement            statementKind
 	whereClauseStatement string
 	selectStatement      string
-	joinClauseStatement  string
+	selectExpressions    []string
+	joins                []clause.JoinParser
 	lockClauseStatement  string
 	tailClauseStatement  string
+	groupClause          string
+	orderClause          string
+	limitClause          string
+	offsetClause         string
+	limitValues          int
+	offsetValues         int
+	err                  error
 	Values               []any
 }
 
@@ -69,6 +117,20 @@ type Builder interface {
 	OrWhereYear(field string, operator clause.Operator, value any) *SQLBuilder
 	WhereDay(field string, operator clause.Operator, value any) *SQLBuilder
 	OrWhereDay(field string, operator clause.Operator, value any) *SQLBuilder
+	WhereJSON(field string, operator clause.Operator, value any) *SQLBuilder
+	OrWhereJSON(field string, operator clause.Operator, value any) *SQLBuilder
+	WhereJSONContains(field string, value any) *SQLBuilder
+	OrWhereJSONContains(field string, value any) *SQLBuilder
+	WhereJSONLength(field string, operator clause.Operator, value any) *SQLBuilder
+	OrWhereJSONLength(field string, operator clause.Operator, value any) *SQLBuilder
+	WhereNull(field string) *SQLBuilder
+	OrWhereNull(field string) *SQLBuilder
+	WhereNotNull(field string) *SQLBuilder
+	OrWhereNotNull(field string) *SQLBuilder
+	WhereMap(conditions map[string]any) *SQLBuilder
+	WhereStruct(example any) *SQLBuilder
+	WhereFullText(columns []string, query string, opts ...clause.FullTextOptions) *SQLBuilder
+	OrWhereFullText(columns []string, query string, opts ...clause.FullTextOptions) *SQLBuilder
 	Join(table string, first string, operator clause.Operator, second string) *SQLBuilder
 	LeftJoin(table string, first string, operator clause.Operator, second string) *SQLBuilder
 	RightJoin(table string, first string, operator clause.Operator, second string) *SQLBuilder
@@ -77,14 +139,23 @@ type Builder interface {
 	GroupBy(columns ...string) *SQLBuilder
 	Limit(n int64) *SQLBuilder
 	Offset(n int64) *SQLBuilder
+	Scope(scopes ...ScopeFunc) *SQLBuilder
+	ApplyScope(names ...string) *SQLBuilder
+	WithTrashed() *SQLBuilder
+	OnlyTrashed() *SQLBuilder
+	WithoutGlobalScope(names ...string) *SQLBuilder
 }
 
 func New(dialect clause.SQLDialector, db *sql.DB, opts ...Option) *SQLBuilder {
 	builder := &SQLBuilder{
-		Dialect:       dialect,
-		sql:           db,
-		enableLogging: true,
-		logger:        log.Default(),
+		Dialect:         dialect,
+		sql:             db,
+		enableLogging:   true,
+		logger:          log.Default(),
+		createdAtColumn: DefaultCreatedAtColumn,
+		updatedAtColumn: DefaultUpdatedAtColumn,
+		clock:           time.Now,
+		primaryKey:      DefaultPrimaryKey,
 	}
 
 	for _, opt := range opts {
@@ -118,13 +189,9 @@ func (s *SQLBuilder) Begin(tx func(s *SQLBuilder) error) error {
 
 	defer transaction.Rollback()
 
-	builder := &SQLBuilder{
-		tx:            transaction,
-		isTx:          true,
-		Dialect:       s.Dialect,
-		enableLogging: s.enableLogging,
-		logger:        s.logger,
-	}
+	builder := s.newBuilder()
+	builder.tx = transaction
+	builder.isTx = true
 
 	err = tx(builder)
 
@@ -151,102 +218,440 @@ func (b *SQLBuilder) Select(columns ...string) *SQLBuilder {
 
 	stmt, _ := selectStatement.Parse(b.Dialect)
 	b.selectStatement = stmt
+	for _, expr := range b.selectExpressions {
+		b.selectStatement = strings.Replace(b.selectStatement, " FROM ", ","+expr+" FROM ", 1)
+	}
 
 	return b
 }
 
+// Insert inserts the data and returns its key. On PostgreSQL, whose drivers do not implement LastInsertId,
+// the key is read back with RETURNING and must be an integer, see insertKey.
 func (s *SQLBuilder) Insert(data any) (int64, error) {
-	dataMap := map[string]any{}
-	dataType := reflect.TypeOf(data)
-
-	if dataType.Kind() == reflect.Struct {
-		err := toMap(data, &dataMap)
+	dataMap, err := toDataMap(data, mapInsert)
+	if err != nil {
+		return 0, err
+	}
 
+	if s.Dialect.GetName() == dialect.PostgreSQL {
+		key, err := s.insertKey(data)
 		if err != nil {
 			return 0, err
 		}
-	} else {
-		dataMap = data.(map[string]any)
+
+		s.returning = []string{key}
+		if err := s.prepareInsert([]map[string]any{dataMap}); err != nil {
+			return 0, err
+		}
+
+		var id int64
+		if err := s.queryReturning(&id); err != nil {
+			return 0, err
+		}
+
+		return id, nil
 	}
 
-	insertStatement := clause.Insert{
-		Table: s.tempTable,
-		Rows: []map[string]any{
-			dataMap,
+	if err := s.prepareInsert([]map[string]any{dataMap}); err != nil {
+		return 0, err
+	}
+
+	res, err := s.Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// InsertMany inserts a []map[string]any, or a slice of structs or struct pointers mapped through their db tags.
+// Every map row must have the same columns, ErrColumnMismatch is returned otherwise. Struct rows leaving
+// an omitempty or pk field empty while other rows set it write DEFAULT for it.
+func (s *SQLBuilder) InsertMany(data any) (sql.Result, error) {
+	rows, err := toRows(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareInsert(rows); err != nil {
+		return nil, err
+	}
+
+	return s.Exec()
+}
+
+// Replace inserts the data, deleting first the row conflicting with it on a unique key. It is supported on MySQL and SQLite.
+func (s *SQLBuilder) Replace(data any) (int64, error) {
+	dataMap, err := toDataMap(data, mapInsert)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.replace([]map[string]any{dataMap})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// ReplaceMany is Replace for the rows accepted by InsertMany.
+func (s *SQLBuilder) ReplaceMany(data any) (sql.Result, error) {
+	rows, err := toRows(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replace(rows)
+}
+
+func (s *SQLBuilder) replace(rows []map[string]any) (sql.Result, error) {
+	if s.Dialect.GetName() == dialect.PostgreSQL {
+		return nil, ErrReplaceUnsupported
+	}
+
+	rows, err := s.insertRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	replaceStatement := clause.Replace{
+		Insert: clause.Insert{
+			Table: s.tempTable,
+			Rows:  rows,
 		},
 	}
 
-	stmt, insert := insertStatement.Parse(s.Dialect)
+	stmt, replace := replaceStatement.Parse(s.Dialect)
+	s.statement = insertKind
 	s.rawStatement = stmt
-	s.Values = append(s.Values, insert.Values...)
+	s.Values = append(s.Values, replace.Values...)
 
-	res, err := s.Exec()
+	return s.Exec()
+}
+
+// InsertReturning inserts the data and scans the columns selected with Returning, all of them by default, into dest.
+func (s *SQLBuilder) InsertReturning(data any, dest any) error {
+	dataMap, err := toDataMap(data, mapInsert)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	lastInsertId, err := res.LastInsertId()
+	if err := s.prepareInsert([]map[string]any{dataMap}); err != nil {
+		return err
+	}
 
+	return s.queryReturning(dest)
+}
+
+func (s *SQLBuilder) prepareInsert(rows []map[string]any) error {
+	rows, err := s.insertRows(rows)
 	if err != nil {
-		return 0, nil
+		return err
 	}
 
-	return lastInsertId, nil
+	s.buildInsert(rows)
+	return nil
 }
 
-func (s *SQLBuilder) InsertMany(data []map[string]any) (sql.Result, error) {
+// insertRows fills the scoped and timestamp columns of the rows and checks they all have the same, non-empty, columns.
+func (s *SQLBuilder) insertRows(rows []map[string]any) ([]map[string]any, error) {
+	rows = s.timestampRows(s.scopeRows(rows))
+	if err := checkColumns(rows); err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		if len(row) == 0 {
+			return nil, fmt.Errorf("%w: row %d is empty", ErrNoColumns, i)
+		}
+
+		for column, value := range row {
+			if value, ok := value.(defaultValue); ok {
+				row[column] = s.defaultValue(value)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// defaultValue renders DEFAULT, SQLite has no DEFAULT keyword in VALUES and gets the fallback value instead.
+func (s *SQLBuilder) defaultValue(value defaultValue) any {
+	if s.Dialect.GetName() == dialect.SQLite {
+		return value.fallback
+	}
+
+	return clause.Raw("DEFAULT")
+}
+
+func (s *SQLBuilder) buildInsert(rows []map[string]any) {
 	insertStatement := clause.Insert{
 		Table: s.tempTable,
-		Rows:  data,
+		Rows:  rows,
 	}
+
 	stmt, insert := insertStatement.Parse(s.Dialect)
-	s.rawStatement = stmt
+	s.statement = insertKind
+	s.rawStatement = stmt + s.returningClause()
 	s.Values = append(s.Values, insert.Values...)
+}
+
+// InsertUsing inserts into columns the rows selected by the query built in the callback.
+// Timestamps and global scope columns are not filled in, the query selects every inserted value.
+func (s *SQLBuilder) InsertUsing(columns []string, builder func(b Builder) *SQLBuilder) (sql.Result, error) {
+	s.prepareInsertUsing(columns, builder)
+
+	return s.Exec()
+}
+
+func (s *SQLBuilder) prepareInsertUsing(columns []string, builder func(b Builder) *SQLBuilder) {
+	newBuilder := builder(s.newNestedBuilder())
+
+	insertStatement := clause.InsertSelect{
+		Table:   s.tempTable,
+		Columns: columns,
+		Query:   newBuilder.GetSql(),
+	}
+
+	s.Values = append(s.Values, newBuilder.GetArguments()...)
+	s.syncPlaceholders()
+	s.statement = insertKind
+	s.rawStatement = insertStatement.Parse(s.Dialect) + s.returningClause()
+}
+
+// Upsert inserts the rows and, for the rows conflicting on conflictColumns, overwrites updateColumns with the inserted values.
+func (s *SQLBuilder) Upsert(rows []map[string]any, conflictColumns []string, updateColumns []string) (sql.Result, error) {
+	if s.usesTimestamps() && s.updatedAtColumn != "" && len(updateColumns) > 0 && !slices.Contains(updateColumns, s.updatedAtColumn) {
+		updateColumns = append(slices.Clone(updateColumns), s.updatedAtColumn)
+	}
+
+	return s.upsert(clause.Upsert{
+		ConflictColumns: conflictColumns,
+		UpdateColumns:   updateColumns,
+	}, rows)
+}
+
+// UpsertWith inserts the rows and applies updates to the conflicting ones. Update values may be
+// bound values or expressions such as clause.Excluded("count") or clause.Raw("count + ?", 1).
+func (s *SQLBuilder) UpsertWith(rows []map[string]any, conflictColumns []string, updates map[string]any) (sql.Result, error) {
+	return s.upsert(clause.Upsert{
+		ConflictColumns: conflictColumns,
+		Updates:         s.timestampUpdate(updates),
+	}, rows)
+}
+
+// InsertOrIgnore inserts the rows, skipping the ones conflicting with existing rows.
+func (s *SQLBuilder) InsertOrIgnore(rows []map[string]any, conflictColumns ...string) (sql.Result, error) {
+	return s.upsert(clause.Upsert{
+		ConflictColumns: conflictColumns,
+		Ignore:          true,
+	}, rows)
+}
+
+func (s *SQLBuilder) upsert(upsertStatement clause.Upsert, rows []map[string]any) (sql.Result, error) {
+	rows, err := s.insertRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	upsertStatement.Insert = clause.Insert{
+		Table: s.tempTable,
+		Rows:  rows,
+	}
+
+	stmt, upsert := upsertStatement.Parse(s.Dialect)
+	s.statement = insertKind
+	s.rawStatement = stmt
+	s.Values = append(s.Values, upsert.Values...)
 
 	return s.Exec()
 }
 
 func (s *SQLBuilder) Update(data any) (sql.Result, error) {
-	dataMap := map[string]any{}
-	dataType := reflect.TypeOf(data)
+	if err := s.prepareUpdate(data); err != nil {
+		return nil, err
+	}
 
-	if dataType.Kind() == reflect.Struct {
-		err := toMap(data, &dataMap)
+	return s.Exec()
+}
 
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		dataMap = data.(map[string]any)
+// UpdateReturning updates the matching rows and scans the columns selected with Returning into dest.
+func (s *SQLBuilder) UpdateReturning(data any, dest any) error {
+	if err := s.prepareUpdate(data); err != nil {
+		return err
+	}
+
+	return s.queryReturning(dest)
+}
+
+// Increment adds amount to the column of the matching rows in a single statement, along with the extra updates, which may be nil.
+func (s *SQLBuilder) Increment(column string, amount any, extra map[string]any) (sql.Result, error) {
+	return s.Update(s.counterUpdate(column, "+", amount, extra))
+}
+
+// Decrement subtracts amount from the column of the matching rows in a single statement, along with the extra updates, which may be nil.
+func (s *SQLBuilder) Decrement(column string, amount any, extra map[string]any) (sql.Result, error) {
+	return s.Update(s.counterUpdate(column, "-", amount, extra))
+}
+
+func (s *SQLBuilder) counterUpdate(column string, operator string, amount any, extra map[string]any) map[string]any {
+	data := make(map[string]any, len(extra)+1)
+	for k, v := range extra {
+		data[k] = v
+	}
+	data[column] = clause.Raw(s.QuoteColumn(column)+" "+operator+" ?", amount)
+
+	return data
+}
+
+func (s *SQLBuilder) prepareUpdate(data any) error {
+	if err := s.guardFullTable(); err != nil {
+		return err
+	}
+
+	if err := s.checkScopedJoins(); err != nil {
+		return err
+	}
+
+	if err := s.checkJoinedTail(); err != nil {
+		return err
+	}
+	s.statement = mutationKind
+
+	dataMap, err := toDataMap(data, mapUpdate)
+	if err != nil {
+		return err
 	}
 
 	updateStatement := clause.Update{
 		Table: s.tempTable,
-		Rows:  dataMap,
+		Rows:  s.timestampUpdate(s.unqualifiedColumns(dataMap)),
+	}
+	if len(updateStatement.Rows) == 0 {
+		return ErrNoColumns
+	}
+	if len(s.joins) > 0 && s.Dialect.GetName() == dialect.MySQL {
+		updateStatement.Table += " " + s.mutationJoins()
 	}
 
-	s.rawStatement = s.whereClauseStatement
 	stmt, update := updateStatement.Parse(s.Dialect, len(s.Values)+1)
-	s.rawStatement = stmt + " " + s.rawStatement
 
 	updateValues := update.Values
 	if s.Dialect.GetName() == dialect.PostgreSQL {
 		s.Values = append(s.Values, updateValues...)
 	} else {
 		s.Values = append(updateValues, s.Values...) // for MySQL and SQLite, update values should be placed before where clause values
+		s.leadingValues += len(updateValues)
 	}
 
-	return s.Exec()
+	// the where clause is rendered last so that implicit placeholders follow the update values
+	s.rawStatement = stmt
+
+	if s.emulatesMutationTail() {
+		s.rawStatement = stmt + " WHERE " + s.keySubquery() + s.returningClause()
+		return nil
+	}
+
+	where := s.whereClause()
+
+	if len(s.joins) > 0 && s.Dialect.GetName() != dialect.MySQL {
+		from, conditions, err := s.mutationFrom()
+		if err != nil {
+			return err
+		}
+
+		stmt += " FROM " + from
+		if conditions != "" {
+			where = prependImplicitCondition(where, conditions)
+		}
+	}
+
+	s.rawStatement = joinStatement(stmt, where, s.tailClauseStatement) + s.returningClause()
+
+	return nil
 }
 
 func (s *SQLBuilder) Delete() (sql.Result, error) {
+	if err := s.prepareDelete(); err != nil {
+		return nil, err
+	}
+
+	return s.Exec()
+}
+
+// DeleteReturning deletes the matching rows and scans the columns selected with Returning into dest.
+func (s *SQLBuilder) DeleteReturning(dest any) error {
+	if err := s.prepareDelete(); err != nil {
+		return err
+	}
+
+	return s.queryReturning(dest)
+}
+
+func (s *SQLBuilder) prepareDelete() error {
+	if column, ok := s.softDeleteColumn(); ok {
+		return s.prepareUpdate(map[string]any{column: s.now()})
+	}
+
+	return s.prepareForceDelete()
+}
+
+func (s *SQLBuilder) prepareForceDelete() error {
+	if err := s.guardFullTable(); err != nil {
+		return err
+	}
+
+	if err := s.checkScopedJoins(); err != nil {
+		return err
+	}
+
+	if err := s.checkJoinedTail(); err != nil {
+		return err
+	}
+	s.statement = mutationKind
+
 	deleteStatement := clause.Delete{
 		Table: s.tempTable,
 	}
 
-	s.rawStatement = s.whereClauseStatement
 	stmt, _ := deleteStatement.Parse(s.Dialect)
-	s.rawStatement = stmt + " " + s.rawStatement
+	s.rawStatement = stmt
+
+	if s.emulatesMutationTail() {
+		s.rawStatement = stmt + " WHERE " + s.keySubquery() + s.returningClause()
+		return nil
+	}
+
+	if len(s.joins) > 0 {
+		return s.prepareJoinedDelete()
+	}
+
+	s.rawStatement = joinStatement(stmt, s.whereClause(), s.tailClauseStatement) + s.returningClause()
+
+	return nil
+}
+
+// Truncate removes every row of the table, with DELETE FROM on SQLite. Like a Delete without conditions,
+// it must be confirmed with AllowFullTable or WithFullTableWrites, and it refuses to run on a table
+// restricted by a global scope.
+func (s *SQLBuilder) Truncate(opts ...clause.TruncateOptions) (sql.Result, error) {
+	if !s.allowFullTable && !s.fullTableWrites {
+		return nil, ErrMissingWhere
+	}
+
+	if _, scoped, _ := s.globalScopeClauses(); len(scoped) > 0 {
+		return nil, fmt.Errorf("sqlbuilder: cannot truncate %s while a global scope applies to it, use WithoutGlobalScope", s.table)
+	}
+
+	truncateStatement := clause.Truncate{
+		Table: s.tempTable,
+	}
+	if len(opts) > 0 {
+		truncateStatement.Options = opts[0]
+	}
+
+	s.rawStatement = truncateStatement.Parse(s.Dialect)
 
 	return s.Exec()
 }
@@ -257,6 +662,7 @@ func (s *SQLBuilder) Table(table string) *SQLBuilder {
 
 	tableName := fmt.Sprintf("%s%s%s", s.Dialect.GetColumnQuoteLeft(), table, s.Dialect.GetColumnQuoteRight())
 	s.tempTable = tableName
+	s.table = table
 
 	selectStatement := clause.Select{
 		Table:   s.tempTable,
@@ -274,12 +680,12 @@ func (s *SQLBuilder) GetSql() string {
 	}
 
 	statement := s.selectStatement
-	if s.joinClauseStatement != "" {
-		statement = statement + " " + s.joinClauseStatement
+	if join := s.joinClause(); join != "" {
+		statement = statement + " " + join
 	}
 
-	if s.whereClauseStatement != "" {
-		statement = statement + " " + s.whereClauseStatement
+	if where := s.whereClause(); where != "" {
+		statement = statement + " " + where
 	}
 
 	if s.lockClauseStatement != "" {
@@ -293,8 +699,33 @@ func (s *SQLBuilder) GetSql() string {
 	return statement
 }
 
+// QuoteColumn quotes a column name, or a table qualified column, for the builder's dialect.
+func (s *SQLBuilder) QuoteColumn(column string) string {
+	return pkg.ColumnSplitter(column, s.Dialect.GetColumnQuoteLeft(), s.Dialect.GetColumnQuoteRight())
+}
+
 func (s *SQLBuilder) GetArguments() []any {
-	return s.Values
+	if s.statement == insertKind {
+		return s.Values
+	}
+
+	_, _, scoped := s.globalScopeClauses()
+	if len(scoped) == 0 {
+		return s.Values
+	}
+
+	if s.Dialect.GetName() == dialect.PostgreSQL {
+		return append(slices.Clone(s.Values), scoped...)
+	}
+
+	// the implicit conditions come right after the select and update values
+	leading := min(s.leadingValues, len(s.Values))
+	args := make([]any, 0, len(s.Values)+len(scoped))
+	args = append(args, s.Values[:leading]...)
+	args = append(args, scoped...)
+	args = append(args, s.Values[leading:]...)
+
+	return args
 }
 
 func (s *SQLBuilder) Where(field string, Op clause.Operator, val any) *SQLBuilder {
@@ -369,6 +800,141 @@ func (s *SQLBuilder) OrWhereDay(field string, operator clause.Operator, value an
 	return s.addWhereDay(field, operator, value, clause.ConjuctionOr)
 }
 
+func (s *SQLBuilder) WhereNull(field string) *SQLBuilder {
+	return s.addWhereNull(field, false, clause.ConjuctionAnd)
+}
+
+func (s *SQLBuilder) OrWhereNull(field string) *SQLBuilder {
+	return s.addWhereNull(field, false, clause.ConjuctionOr)
+}
+
+func (s *SQLBuilder) WhereNotNull(field string) *SQLBuilder {
+	return s.addWhereNull(field, true, clause.ConjuctionAnd)
+}
+
+func (s *SQLBuilder) OrWhereNotNull(field string) *SQLBuilder {
+	return s.addWhereNull(field, true, clause.ConjuctionOr)
+}
+
+// WhereMap adds an equality condition for every key of the map. Nil values become IS NULL
+// and slices become IN conditions.
+func (s *SQLBuilder) WhereMap(conditions map[string]any) *SQLBuilder {
+	keys := make([]string, 0, len(conditions))
+	for k := range conditions {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		val := conditions[k]
+
+		if val == nil {
+			s.WhereNull(k)
+			continue
+		}
+
+		if values, ok := toSliceOfAny(val); ok {
+			s.WhereIn(k, values)
+			continue
+		}
+
+		s.Where(k, clause.OperatorEqual, val)
+	}
+
+	return s
+}
+
+// WhereStruct adds an equality condition for every non-zero field of the example struct,
+// using the same db tags that ScanStruct reads. When example is not a struct the error is
+// returned by the query instead of running it without the conditions.
+func (s *SQLBuilder) WhereStruct(example any) *SQLBuilder {
+	v, err := structValue(example)
+	if err != nil {
+		s.err = err
+		return s
+	}
+
+	return s.WhereMap(columnValues(v, mapConditions))
+}
+
+func (s *SQLBuilder) WhereJSON(field string, operator clause.Operator, value any) *SQLBuilder {
+	return s.addWhereJSON(field, operator, value, clause.ConjuctionAnd)
+}
+
+func (s *SQLBuilder) OrWhereJSON(field string, operator clause.Operator, value any) *SQLBuilder {
+	return s.addWhereJSON(field, operator, value, clause.ConjuctionOr)
+}
+
+func (s *SQLBuilder) WhereJSONContains(field string, value any) *SQLBuilder {
+	return s.addWhereJSONContains(field, value, clause.ConjuctionAnd)
+}
+
+func (s *SQLBuilder) OrWhereJSONContains(field string, value any) *SQLBuilder {
+	return s.addWhereJSONContains(field, value, clause.ConjuctionOr)
+}
+
+func (s *SQLBuilder) WhereJSONLength(field string, operator clause.Operator, value any) *SQLBuilder {
+	return s.addWhereJSONLength(field, operator, value, clause.ConjuctionAnd)
+}
+
+func (s *SQLBuilder) OrWhereJSONLength(field string, operator clause.Operator, value any) *SQLBuilder {
+	return s.addWhereJSONLength(field, operator, value, clause.ConjuctionOr)
+}
+
+func (s *SQLBuilder) WhereFullText(columns []string, query string, opts ...clause.FullTextOptions) *SQLBuilder {
+	return s.addWhereFullText(columns, query, opts, clause.ConjuctionAnd)
+}
+
+func (s *SQLBuilder) OrWhereFullText(columns []string, query string, opts ...clause.FullTextOptions) *SQLBuilder {
+	return s.addWhereFullText(columns, query, opts, clause.ConjuctionOr)
+}
+
+// SelectRelevance adds the full-text relevance score of the query to the selected columns under alias.
+func (s *SQLBuilder) SelectRelevance(columns []string, query string, alias string, opts ...clause.FullTextOptions) *SQLBuilder {
+	relevance := clause.FullTextRelevance{
+		Table:   s.tempTable,
+		Columns: columns,
+		Query:   query,
+		Options: fullTextOptions(opts),
+	}
+
+	expr := fmt.Sprintf("%s AS %s%s%s", relevance.Parse(s.Dialect), s.Dialect.GetColumnQuoteLeft(), alias, s.Dialect.GetColumnQuoteRight())
+	s.selectStatement = strings.Replace(s.selectStatement, " FROM ", ","+expr+" FROM ", 1)
+	// kept rendered so a later Select keeps the expression and the placeholders of its bound values
+	s.selectExpressions = append(s.selectExpressions, expr)
+
+	if s.Dialect.GetName() == dialect.PostgreSQL {
+		s.Values = append(s.Values, relevance.GetArguments(s.Dialect)...)
+	} else {
+		s.Values = append(relevance.GetArguments(s.Dialect), s.Values...) // select values are placed before the other clause values
+		s.leadingValues += len(relevance.GetArguments(s.Dialect))
+	}
+
+	return s
+}
+
+// OrderByRelevance orders the results by full-text relevance, best matches first.
+func (s *SQLBuilder) OrderByRelevance(columns []string, query string, opts ...clause.FullTextOptions) *SQLBuilder {
+	relevance := clause.FullTextRelevance{
+		Table:   s.tempTable,
+		Columns: columns,
+		Query:   query,
+		Options: fullTextOptions(opts),
+	}
+	order := clause.Order{
+		OrderingFields: []clause.OrderField{
+			{
+				Field:     relevance.Parse(s.Dialect),
+				Direction: relevance.Direction(s.Dialect),
+			},
+		},
+	}
+	s.addOrder(order)
+	s.addTailValues(s.limitValues+s.offsetValues, relevance.GetArguments(s.Dialect)...)
+
+	return s
+}
+
 func (s *SQLBuilder) LockForUpdate() *SQLBuilder {
 	s.lockClauseStatement = clause.ForUpdate{IsLocking: true}.Parse()
 	return s
@@ -389,7 +955,7 @@ func (s *SQLBuilder) Join(table string, first string, operator clause.Operator,
 			RightField: second,
 		},
 	}
-	s.joinClauseStatement = s.concatJoinClause(s.joinClauseStatement, join)
+	s.joins = append(s.joins, join)
 	return s
 }
 
@@ -403,7 +969,7 @@ func (s *SQLBuilder) LeftJoin(table string, first string, operator clause.Operat
 			RightField: second,
 		},
 	}
-	s.joinClauseStatement = s.concatJoinClause(s.joinClauseStatement, join)
+	s.joins = append(s.joins, join)
 	return s
 }
 
@@ -417,7 +983,7 @@ func (s *SQLBuilder) RightJoin(table string, first string, operator clause.Opera
 			RightField: second,
 		},
 	}
-	s.joinClauseStatement = s.concatJoinClause(s.joinClauseStatement, join)
+	s.joins = append(s.joins, join)
 	return s
 }
 
@@ -425,7 +991,7 @@ func (s *SQLBuilder) CrossJoin(table string) *SQLBuilder {
 	join := clause.CrossJoin{
 		SecondTable: table,
 	}
-	s.joinClauseStatement = s.concatJoinClause(s.joinClauseStatement, join)
+	s.joins = append(s.joins, join)
 	return s
 }
 
@@ -454,35 +1020,70 @@ func (s *SQLBuilder) OrderBy(column string, dir clause.OrderDirection) *SQLBuild
 			},
 		},
 	}
-	s.tailClauseStatement = s.tailClauseStatement + order.Parse(s.Dialect)
+	s.addOrder(order)
 	return s
 }
 
+// addOrder adds the ordering fields to the ORDER BY clause, starting it when there is none yet.
+func (s *SQLBuilder) addOrder(order clause.Order) {
+	if s.orderClause == "" {
+		s.orderClause = order.Parse(s.Dialect)
+	} else {
+		s.orderClause += ", " + strings.TrimPrefix(order.Parse(s.Dialect), "ORDER BY ")
+	}
+	s.renderTail()
+}
+
+// renderTail renders the tail clauses in the order SQL expects them, whatever order they were added in.
+func (s *SQLBuilder) renderTail() {
+	s.tailClauseStatement = joinStatement(s.groupClause, s.orderClause, s.limitClause, s.offsetClause)
+}
+
+// addTailValues binds the values of a tail clause. Placeholders are positional outside PostgreSQL,
+// so the values go before the following values, bound by the clauses rendered after it.
+func (s *SQLBuilder) addTailValues(following int, values ...any) {
+	if s.Dialect.GetName() == dialect.PostgreSQL || following == 0 {
+		s.Values = append(s.Values, values...)
+		return
+	}
+
+	s.Values = slices.Insert(s.Values, len(s.Values)-following, values...)
+}
+
 func (s *SQLBuilder) GroupBy(columns ...string) *SQLBuilder {
 	grouping := clause.GroupBy{
 		Fields: columns,
 	}
-	s.tailClauseStatement = s.concatTailClause(s.tailClauseStatement, grouping)
+	if s.groupClause == "" {
+		s.groupClause = grouping.Parse(s.Dialect)
+	} else if len(columns) > 0 {
+		s.groupClause += "," + strings.TrimPrefix(grouping.Parse(s.Dialect), "GROUP BY ")
+	}
+	s.renderTail()
 	return s
 }
 func (s *SQLBuilder) Limit(n int64) *SQLBuilder {
 	limit := clause.Limit{
 		Count: n,
 	}
-	s.tailClauseStatement = s.concatTailClause(s.tailClauseStatement, limit)
+	s.limitClause = limit.Parse(s.Dialect)
 	if limit.Count != 0 {
-		s.Values = append(s.Values, n)
+		s.addTailValues(s.offsetValues, n)
+		s.limitValues = 1
 	}
+	s.renderTail()
 	return s
 }
 func (s *SQLBuilder) Offset(n int64) *SQLBuilder {
 	offset := clause.Offset{
 		Count: n,
 	}
-	s.tailClauseStatement = s.concatTailClause(s.tailClauseStatement, offset)
+	s.offsetClause = strings.TrimSpace(offset.Parse(s.Dialect))
 	if offset.Count != 0 {
 		s.Values = append(s.Values, n)
+		s.offsetValues = 1
 	}
+	s.renderTail()
 	return s
 }
 
@@ -500,43 +1101,9 @@ func (s *SQLBuilder) When(condition bool, builder func(b Builder) *SQLBuilder) *
 }
 
 func (b *SQLBuilder) Get(d any) error {
-	ctx := context.Background()
-	rows, err := b.runQuery(ctx)
-	if err != nil {
-		return err
-	}
-
-	defer rows.Close()
-
-	ref := reflect.TypeOf(d)
-	if ref.Kind() == reflect.Ptr {
-		ref = ref.Elem()
-	}
-
-	if ref.Kind() == reflect.Struct {
-		if rows.Next() {
-			if err = ScanStruct(d, rows); err != nil {
-				return err
-			}
-		}
-	}
-
-	if ref.Kind() == reflect.Map {
-		if rows.Next() {
-			if err = ScanMap(d, rows); err != nil {
-				return err
-			}
-		}
-	}
-
-	if ref.Kind() == reflect.Slice {
-		if err = ScanAll(d, rows); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return b.GetContext(d, context.Background())
 }
+
 func (b *SQLBuilder) GetContext(d any, ctx context.Context) error {
 	rows, err := b.runQuery(ctx)
 	if err != nil {
@@ -545,34 +1112,7 @@ func (b *SQLBuilder) GetContext(d any, ctx context.Context) error {
 
 	defer rows.Close()
 
-	ref := reflect.TypeOf(d)
-	if ref.Kind() == reflect.Ptr {
-		ref = ref.Elem()
-	}
-
-	if ref.Kind() == reflect.Struct {
-		if rows.Next() {
-			if err = ScanStruct(d, rows); err != nil {
-				return err
-			}
-		}
-	}
-
-	if ref.Kind() == reflect.Map {
-		if rows.Next() {
-			if err = ScanMap(d, rows); err != nil {
-				return err
-			}
-		}
-	}
-
-	if ref.Kind() == reflect.Slice {
-		if err = ScanAll(d, rows); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return b.scan(d, rows)
 }
 
 func (b *SQLBuilder) Count() (int64, error) {
@@ -660,6 +1200,10 @@ func (b *SQLBuilder) Scan(d interface{}) error {
 	return nil
 }
 func (s *SQLBuilder) Exec() (sql.Result, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
 	statement := s.GetSql()
 	arguments := s.GetArguments()
 
@@ -673,6 +1217,10 @@ func (s *SQLBuilder) Exec() (sql.Result, error) {
 	return s.sql.Exec(statement, arguments...)
 }
 func (s *SQLBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
 	statement := s.GetSql()
 	arguments := s.GetArguments()
 
@@ -688,12 +1236,34 @@ func (s *SQLBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 
 func (s *SQLBuilder) clearStatement() {
 	s.rawStatement = ""
+	s.statement = selectKind
 	s.tempTable = ""
+	s.table = ""
+	s.trashed = withoutTrashed
+	s.withoutTimestamps = false
+	s.allowFullTable = false
+	s.returning = nil
 	s.selectStatement = ""
-	s.joinClauseStatement = ""
+	s.selectExpressions = nil
+	s.err = nil
+	s.joins = nil
 	s.whereClauseStatement = ""
 	s.lockClauseStatement = ""
 	s.tailClauseStatement = ""
+	s.groupClause = ""
+	s.orderClause = ""
+	s.limitClause = ""
+	s.offsetClause = ""
+	s.limitValues = 0
+	s.offsetValues = 0
+	s.leadingValues = 0
+
+	if s.nested {
+		return
+	}
+
+	s.withoutScopes = nil
+	s.withoutAllScopes = false
 	s.resetDialectState()
 }
 
@@ -717,6 +1287,54 @@ func (s *SQLBuilder) concatWhereClause(statement string, conj clause.Conjuction,
 	return stmt
 }
 
+// whereClause returns the where clause with the conditions the builder adds implicitly.
+func (s *SQLBuilder) whereClause() string {
+	stmt := s.whereClauseStatement
+
+	if _, scoped, _ := s.globalScopeClauses(); len(scoped) > 0 {
+		stmt = prependImplicitCondition(stmt, strings.Join(scoped, " "+string(clause.ConjuctionAnd)+" "))
+	}
+
+	if column, ok := s.softDeleteColumn(); ok && s.trashed != withTrashed {
+		trashed := clause.WhereNull{
+			Field: s.table + "." + column,
+			Not:   s.trashed == onlyTrashed,
+		}
+		stmt = appendImplicitCondition(stmt, trashed.Parse(s.Dialect))
+	}
+
+	return stmt
+}
+
+// appendImplicitCondition ANDs condition to the where clause, grouping the existing
+// conditions first so that an OR cannot bypass it.
+func appendImplicitCondition(statement string, condition string) string {
+	if statement == "" {
+		return "WHERE " + condition
+	}
+
+	if strings.Contains(statement, " "+string(clause.ConjuctionOr)+" ") {
+		return "WHERE (" + strings.TrimPrefix(statement, "WHERE ") + ") AND " + condition
+	}
+
+	return statement + " AND " + condition
+}
+
+// prependImplicitCondition puts condition before the conditions of the where clause, grouping them first
+// when they contain an OR.
+func prependImplicitCondition(statement string, condition string) string {
+	conditions := strings.TrimPrefix(statement, "WHERE ")
+
+	switch {
+	case statement == "":
+		return "WHERE " + condition
+	case strings.Contains(conditions, " "+string(clause.ConjuctionOr)+" "):
+		return "WHERE " + condition + " AND (" + conditions + ")"
+	default:
+		return "WHERE " + condition + " AND " + conditions
+	}
+}
+
 func (s *SQLBuilder) concatWhereGroup(statement string, conj clause.Conjuction, group string) string {
 	if group == "" {
 		return statement
@@ -754,24 +1372,15 @@ func (s *SQLBuilder) concatWhereWithSubquery(statement string, w clause.Where, s
 	return stmt
 }
 
-func (s *SQLBuilder) concatJoinClause(statement string, join clause.JoinParser) string {
-	stmt := statement
-	if strings.Contains(stmt, "JOIN") {
-		stmt = stmt + " " + join.Parse(s.Dialect)
-		return stmt
-	}
-	stmt = join.Parse(s.Dialect)
-	return stmt
-}
+func (s *SQLBuilder) joinClause() string {
+	joins, _, _ := s.globalScopeClauses()
 
-func (s *SQLBuilder) concatTailClause(statement string, tail clause.TailParser) string {
-	stmt := statement
-	if strings.Contains(stmt, "GROUP BY") || strings.Contains(stmt, "ORDER BY") || strings.Contains(stmt, "LIMIT") || strings.Contains(stmt, "OFFSET") {
-		stmt = stmt + " " + tail.Parse(s.Dialect)
-		return stmt
+	stmts := make([]string, 0, len(joins))
+	for _, join := range joins {
+		stmts = append(stmts, join.Parse(s.Dialect))
 	}
-	stmt = tail.Parse(s.Dialect)
-	return stmt
+
+	return strings.Join(stmts, " ")
 }
 
 func (s *SQLBuilder) addWhere(field string, op clause.Operator, val any, conj clause.Conjuction) *SQLBuilder {
@@ -880,6 +1489,64 @@ func (s *SQLBuilder) addWhereDay(field string, operator clause.Operator, value a
 	return s
 }
 
+func (s *SQLBuilder) addWhereNull(field string, not bool, conj clause.Conjuction) *SQLBuilder {
+	wherenull := clause.WhereNull{
+		Field: field,
+		Not:   not,
+		Conj:  conj,
+	}
+	s.whereClauseStatement = s.concatWhereClause(s.whereClauseStatement, wherenull.Conj, wherenull)
+	return s
+}
+
+func (s *SQLBuilder) addWhereJSON(field string, operator clause.Operator, value any, conj clause.Conjuction) *SQLBuilder {
+	wherejson := clause.WhereJSON{
+		Field: field,
+		Op:    operator,
+		Value: value,
+		Conj:  conj,
+	}
+	s.Values = append(s.Values, value)
+	s.whereClauseStatement = s.concatWhereClause(s.whereClauseStatement, wherejson.Conj, wherejson)
+	return s
+}
+
+func (s *SQLBuilder) addWhereJSONContains(field string, value any, conj clause.Conjuction) *SQLBuilder {
+	wherejson := clause.WhereJSONContains{
+		Field: field,
+		Value: value,
+		Conj:  conj,
+	}
+	s.Values = append(s.Values, wherejson.GetArgument(s.Dialect))
+	s.whereClauseStatement = s.concatWhereClause(s.whereClauseStatement, wherejson.Conj, wherejson)
+	return s
+}
+
+func (s *SQLBuilder) addWhereJSONLength(field string, operator clause.Operator, value any, conj clause.Conjuction) *SQLBuilder {
+	wherejson := clause.WhereJSONLength{
+		Field: field,
+		Op:    operator,
+		Value: value,
+		Conj:  conj,
+	}
+	s.Values = append(s.Values, value)
+	s.whereClauseStatement = s.concatWhereClause(s.whereClauseStatement, wherejson.Conj, wherejson)
+	return s
+}
+
+func (s *SQLBuilder) addWhereFullText(columns []string, query string, opts []clause.FullTextOptions, conj clause.Conjuction) *SQLBuilder {
+	wherefulltext := clause.WhereFullText{
+		Table:   s.tempTable,
+		Columns: columns,
+		Query:   query,
+		Conj:    conj,
+		Options: fullTextOptions(opts),
+	}
+	s.Values = append(s.Values, wherefulltext.GetArgument(s.Dialect))
+	s.whereClauseStatement = s.concatWhereClause(s.whereClauseStatement, wherefulltext.Conj, wherefulltext)
+	return s
+}
+
 func (s *SQLBuilder) addWhereGroup(conj clause.Conjuction, builder func(b Builder) *SQLBuilder) *SQLBuilder {
 	newBuilder := s.newNestedBuilder()
 	newBuilder = builder(newBuilder)
@@ -903,7 +1570,8 @@ func (s *SQLBuilder) addWhereFunc(field string, operator clause.Operator, conj c
 	}
 	childStmt := newBuilder.GetSql()
 
-	s.Values = append(s.Values, newBuilder.Values...)
+	s.Values = append(s.Values, newBuilder.GetArguments()...)
+	s.syncPlaceholders()
 	s.whereClauseStatement = s.concatWhereWithSubquery(s.whereClauseStatement, where, childStmt)
 	return s
 }
@@ -917,24 +1585,61 @@ func (s *SQLBuilder) addWhereExists(conj clause.Conjuction, builder func(b Build
 		Conj: conj,
 	}
 
-	s.Values = append(s.Values, newBuilder.Values...)
+	s.Values = append(s.Values, newBuilder.GetArguments()...)
+	s.syncPlaceholders()
 	s.whereClauseStatement = s.concatWhereWithSubquery(s.whereClauseStatement, where, childStmt)
 
 	return s
 }
 
-func (s *SQLBuilder) newNestedBuilder() *SQLBuilder {
+// newBuilder returns an empty builder sharing the connection and configuration of s.
+func (s *SQLBuilder) newBuilder() *SQLBuilder {
 	return &SQLBuilder{
-		Dialect:       s.Dialect,
-		sql:           s.sql,
-		tx:            s.tx,
-		isTx:          s.isTx,
-		enableLogging: s.enableLogging,
-		logger:        s.logger,
+		Dialect:         s.Dialect,
+		sql:             s.sql,
+		tx:              s.tx,
+		isTx:            s.isTx,
+		enableLogging:   s.enableLogging,
+		logger:          s.logger,
+		softDeletes:     s.softDeletes,
+		globalScopes:    s.globalScopes,
+		timestampTables: s.timestampTables,
+		createdAtColumn: s.createdAtColumn,
+		updatedAtColumn: s.updatedAtColumn,
+		clock:           s.clock,
+		utc:             s.utc,
+		primaryKey:      s.primaryKey,
+		fullTableWrites: s.fullTableWrites,
+		strictScan:      s.strictScan,
+		mapScanMode:     s.mapScanMode,
 	}
 }
 
+func (s *SQLBuilder) newNestedBuilder() *SQLBuilder {
+	builder := s.newBuilder()
+
+	// subqueries keep the parent's placeholder sequence and disabled scopes
+	builder.withoutScopes = s.withoutScopes
+	builder.withoutAllScopes = s.withoutAllScopes
+	builder.nested = true
+	builder.placeholderOffset = s.placeholderOffset + len(s.Values)
+
+	return builder
+}
+
+func fullTextOptions(opts []clause.FullTextOptions) clause.FullTextOptions {
+	if len(opts) == 0 {
+		return clause.FullTextOptions{}
+	}
+
+	return opts[0]
+}
+
 func (s *SQLBuilder) runQuery(ctx context.Context) (*sql.Rows, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
 	sql := s.GetSql()
 	arguments := s.GetArguments()
 
@@ -134,6 +134,15 @@ func TestSelectAll(t *testing.T) {
 	}
 }
 
+func TestSelectAliases(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db)
+	builder.Table("orders").Select("orders.*", "customers.name AS customer__name", "id as order_id", "COUNT(*) AS total")
+
+	if sql := builder.GetSql(); sql != "SELECT `orders`.*,`customers`.`name` AS customer__name,`id` AS order_id,COUNT(*) AS total FROM `orders`" {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
 func TestWithWhere(t *testing.T) {
 	dialect := dialect.New("?", "`", "`")
 	builder = New(dialect, db)
@@ -758,9 +767,9 @@ func TestExecuteInsert(t *testing.T) {
 func TestExecuteInsertWithStructData(t *testing.T) {
 	dba, err := sql.Open("sqlite3", ":memory:")
 	type UserData struct {
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Age      uint64 `json:"age"`
+		Username string `db:"username"`
+		Email    string `db:"email"`
+		Age      uint64 `db:"age"`
 	}
 
 	if err != nil {
@@ -855,6 +864,377 @@ func TestInsertMultipleRows(t *testing.T) {
 	}
 }
 
+func TestExecuteInsertManyStructs(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	type NewUser struct {
+		Username string `db:"username"`
+		Email    string `db:"email"`
+		Age      int    `db:"age"`
+		Note     string `db:"-"`
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	res, err := builder.Table("users").InsertMany([]NewUser{
+		{Username: "alice", Email: "alice@example.com", Age: 29, Note: "ignored"},
+		{Username: "bob", Email: "bob@example.com", Age: 31},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows != 2 {
+		t.Errorf("Expected 2 rows affected, got: %d", rows)
+	}
+
+	res, err = builder.Table("users").InsertMany([]*NewUser{
+		{Username: "carol", Email: "carol@example.com", Age: 40},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows != 1 {
+		t.Errorf("Expected 1 row affected, got: %d", rows)
+	}
+
+	var user User
+	if err = builder.Table("users").Where("username", clause.OperatorEqual, "bob").Get(&user); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Email != "bob@example.com" || user.Age != 31 {
+		t.Errorf("Unexpected inserted row: %v", user)
+	}
+
+	_, err = builder.Table("users").InsertMany([]*NewUser{nil})
+	if err == nil {
+		t.Error("Expected an error for a nil row")
+	}
+
+	_, err = builder.Table("users").InsertMany([]string{"alice"})
+	if err == nil {
+		t.Error("Expected an error for a slice of strings")
+	}
+}
+
+func TestInsertManyColumnMismatch(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db)
+
+	_, err := builder.Table("users").InsertMany([]map[string]any{
+		{"username": "alice", "email": "alice@example.com"},
+		{"username": "bob", "age": 31},
+	})
+	if !errors.Is(err, ErrColumnMismatch) {
+		t.Fatalf("Expected ErrColumnMismatch, got: %v", err)
+	}
+
+	_, err = builder.Table("users").InsertMany([]map[string]any{
+		{"username": "alice", "email": "alice@example.com"},
+		{"username": "bob"},
+	})
+	if !errors.Is(err, ErrColumnMismatch) {
+		t.Fatalf("Expected ErrColumnMismatch, got: %v", err)
+	}
+}
+
+func TestInsertManyStructsWithEmptyFields(t *testing.T) {
+	members := []Member{
+		{Contact: Contact{Email: "alice@example.com"}, Username: "alice"},
+		{Id: 20, Contact: Contact{Email: "bob@example.com"}, Username: "bob", Age: 31},
+	}
+
+	builder := New(dialect.NewMySQL(), db)
+	if err := builder.Table("users").prepareInsert(mustRows(t, members)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "INSERT INTO `users`(`age`,`email`,`id`,`username`) VALUES(DEFAULT,?,DEFAULT,?),(?,?,?,?)"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if args := builder.GetArguments(); len(args) != 6 || args[0] != "alice@example.com" || args[2] != 31 || args[4] != int64(20) {
+		t.Errorf("Unexpected arguments: %v", args)
+	}
+
+	builder = New(dialect.New("?", "`", "`"), db)
+	if err := builder.Table("users").prepareInsert(mustRows(t, members)); err != nil {
+		t.Fatal(err)
+	}
+
+	if args := builder.GetArguments(); len(args) != 8 || args[0] != 0 || args[2] != nil {
+		t.Errorf("Unexpected SQLite arguments: %v", args)
+	}
+
+	builder = New(dialect.NewPostgres(), db)
+	if err := builder.Table("users").prepareInsert(mustRows(t, members[:1])); err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != `INSERT INTO "users"("email","username") VALUES($1,$2)` {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = New(dialect.New("?", "`", "`"), dba).Table("users").InsertMany(members); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int64
+	if err = New(dialect.New("?", "`", "`"), dba).Table("users").WhereIn("username", []any{"ALICE", "BOB"}).OrderBy("id", clause.OrderDirectionASC).Pluck("id", &ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 2 || ids[0] != 11 || ids[1] != 20 {
+		t.Errorf("Unexpected ids: %v", ids)
+	}
+}
+
+func mustRows(t *testing.T, data any) []map[string]any {
+	t.Helper()
+
+	rows, err := toRows(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rows
+}
+
+func TestInsertUsing(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "users")))
+
+	builder.Table("archived_users").prepareInsertUsing([]string{"id", "username"}, func(b Builder) *SQLBuilder {
+		return b.Table("users").Select("id", "username").Where("age", clause.OperatorGreaterThan, 30).WhereIn("role", []any{"guest", "banned"})
+	})
+
+	expected := `INSERT INTO "archived_users"("id","username") SELECT "id","username" FROM "users" WHERE "users"."tenant_id" = $4 AND "age" > $1 AND "role" IN($2,$3)`
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 4 || args[0] != 30 || args[1] != "guest" || args[2] != "banned" || args[3] != 7 {
+		t.Errorf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestExecuteInsertUsing(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = dba.Exec("CREATE TABLE archived_users(id integer primary key, username TEXT, email TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	res, err := builder.Table("archived_users").InsertUsing([]string{"id", "username", "email"}, func(b Builder) *SQLBuilder {
+		return b.Table("users").Select("id", "username", "email").Where("age", clause.OperatorGreaterThan, 30)
+	})
+	if err != nil {
+		t.Fatalf("insert using failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := builder.Table("users").Where("age", clause.OperatorGreaterThan, 30).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected == 0 || affected != count {
+		t.Errorf("Expected %d archived users, got: %d", count, affected)
+	}
+}
+
+func TestIncrementStatement(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db)
+
+	builder.Table("posts").Where("id", clause.OperatorEqual, 1)
+	err := builder.prepareUpdate(builder.counterUpdate("views", "+", 5, map[string]any{"title": "popular"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "posts" SET "title" = $2, "views" = "views" + $3 WHERE "id" = $1`
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 3 || args[0] != 1 || args[1] != "popular" || args[2] != 5 {
+		t.Errorf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestExecuteIncrementAndDecrement(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedPosts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	if _, err = builder.Table("posts").Where("id", clause.OperatorEqual, 1).Increment("views", 5, nil); err != nil {
+		t.Fatalf("increment failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	_, err = builder.Table("posts").Where("id", clause.OperatorEqual, 2).Decrement("views", 3, map[string]any{"title": "less popular"})
+	if err != nil {
+		t.Fatalf("decrement failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	_, err = builder.Table("posts").Where("id", clause.OperatorEqual, 3).Update(map[string]any{"title": clause.Column("views")})
+	if err != nil {
+		t.Fatalf("update failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	var posts []map[string]any
+	if err = builder.Table("posts").Select("title", "views").OrderBy("id", clause.OrderDirectionASC).Get(&posts); err != nil {
+		t.Fatal(err)
+	}
+
+	if posts[0]["views"] != 15 || posts[1]["views"] != 17 || posts[1]["title"] != "less popular" || posts[2]["title"] != "30" {
+		t.Errorf("Unexpected posts: %v", posts)
+	}
+}
+
+func TestUpdateManyStatement(t *testing.T) {
+	rows := []map[string]any{
+		{"id": 1, "views": 100},
+		{"id": 2, "views": 200},
+	}
+
+	builder := New(dialect.NewPostgres(), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "posts")))
+	builder.Table("posts").Where("title", clause.OperatorNot, "draft").OrWhere("views", clause.OperatorGreaterThan, 0)
+	if err := builder.prepareUpdateMany("id", rows); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "posts" SET "views" = CASE "id" WHEN $3 THEN $4 WHEN $5 THEN $6 ELSE "views" END WHERE "id" IN($7,$8) AND ("posts"."tenant_id" = $9 AND ("title" != $1 OR "views" > $2))`
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder = New(dialect.NewMySQL(), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "posts")))
+	builder.Table("posts").Where("title", clause.OperatorNot, "draft")
+	if err := builder.prepareUpdateMany("id", rows); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = "UPDATE `posts` SET `views` = CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `views` END WHERE `id` IN(?,?) AND `posts`.`tenant_id` = ? AND `title` != ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	want := []any{1, 100, 2, 200, 1, 2, 7, "draft"}
+	if len(args) != len(want) {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("Unexpected arguments, got: %v", args)
+		}
+	}
+}
+
+func TestExecuteUpdateMany(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedPosts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	builder := New(dialect.New("?", "`", "`"), dba, WithLogger(log.New(&buf, "", 0)))
+
+	rows := []map[string]any{
+		{"id": 1, "views": 100, "title": "first updated"},
+		{"id": 2, "views": 200},
+		{"id": 3, "views": 300},
+	}
+	for i := 4; i <= 400; i++ {
+		rows = append(rows, map[string]any{"id": i, "views": i})
+	}
+
+	total, err := builder.Table("posts").WhereNull("deleted_at").UpdateMany("id", rows)
+	if err != nil {
+		t.Fatalf("update many failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if total != 2 {
+		t.Errorf("Expected 2 rows affected, got: %d", total)
+	}
+
+	if statements := strings.Count(buf.String(), "UPDATE `posts`"); statements != 3 {
+		t.Errorf("Expected the rows to be split into 3 statements, got: %d", statements)
+	}
+
+	var posts []map[string]any
+	if err = builder.Table("posts").Select("title", "views").OrderBy("id", clause.OrderDirectionASC).Get(&posts); err != nil {
+		t.Fatal(err)
+	}
+
+	if posts[0]["title"] != "first updated" || posts[0]["views"] != 100 || posts[1]["title"] != "second" || posts[1]["views"] != 200 || posts[2]["views"] != 30 {
+		t.Errorf("Unexpected posts: %v", posts)
+	}
+
+	_, err = builder.Table("posts").UpdateMany("id", []map[string]any{{"views": 1}})
+	if !errors.Is(err, ErrColumnMismatch) {
+		t.Errorf("Expected ErrColumnMismatch for a row without key, got: %v", err)
+	}
+
+	_, err = builder.Table("posts").UpdateMany("id", []map[string]any{{"id": 1, "views": 1}, {"id": 2}})
+	if !errors.Is(err, ErrNoColumns) {
+		t.Errorf("Expected ErrNoColumns for a row with only its key, got: %v", err)
+	}
+
+	_, err = builder.Table("posts").Join("users", "users.id", clause.OperatorEqual, "posts.user_id").UpdateMany("id", rows[:1])
+	if !errors.Is(err, ErrUpdateManyJoins) {
+		t.Errorf("Expected ErrUpdateManyJoins, got: %v", err)
+	}
+
+	_, err = builder.Table("posts").Limit(1).UpdateMany("id", rows[:1])
+	if !errors.Is(err, ErrUpdateManyTail) {
+		t.Errorf("Expected ErrUpdateManyTail, got: %v", err)
+	}
+}
+
 func TestExecuteUpdateStatement(t *testing.T) {
 	dba, err := sql.Open("sqlite3", ":memory:")
 
@@ -965,9 +1345,9 @@ func TestExecuteTransaction(t *testing.T) {
 	builder = New(dialect, dba)
 	err = builder.Begin(func(b *SQLBuilder) error {
 		type UserRequest struct {
-			Username string `json:"username"`
-			Age      int    `json:"age"`
-			Email    string `json:"email"`
+			Username string `db:"username"`
+			Age      int    `db:"age"`
+			Email    string `db:"email"`
 		}
 		user := UserRequest{
 			Username: "johncena",
@@ -990,14 +1370,8 @@ func TestExecuteTransaction(t *testing.T) {
 			Age int `db:"age"`
 		}
 		update := UpdateRequest{Age: 40}
-		updateMap := map[string]any{}
-		err = toMap(update, &updateMap)
 
-		if err != nil {
-			return errors.New("failed to convert update struct to map: " + err.Error())
-		}
-
-		if _, err = b.Table("users").Where("id", clause.OperatorEqual, lastInsertId).Update(updateMap); err != nil {
+		if _, err = b.Table("users").Where("id", clause.OperatorEqual, lastInsertId).Update(update); err != nil {
 			return errors.New("failed to update user: " + err.Error())
 		}
 
@@ -1457,3 +1831,468 @@ func TestWhenBuilder(t *testing.T) {
 		t.Fatalf("Unexpected SQL result, got: %s", builder.GetSql())
 	}
 }
+
+func TestExecuteJSONQueries(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dba.Exec(`
+		CREATE TABLE settings(
+			id integer primary key,
+			meta TEXT
+		);
+		INSERT INTO settings values(1, '{"name": "alice", "address": {"city": "Denpasar"}, "tags": ["go", "sql"]}');
+		INSERT INTO settings values(2, '{"name": "bob", "address": {"city": "Jakarta"}, "tags": ["php"]}');
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dialect := dialect.New("?", "`", "`")
+	builder := New(dialect, dba)
+
+	count, err := builder.Table("settings").WhereJSON("meta->address->city", clause.OperatorEqual, "Denpasar").Count()
+	if err != nil {
+		t.Fatalf("json where failed: %v, sql: %s", err, builder.GetSql())
+	}
+	if count != 1 {
+		t.Errorf("Expected count to be %d, but got: %d", 1, count)
+	}
+
+	count, err = builder.Table("settings").WhereJSONContains("meta->tags", "php").Count()
+	if err != nil {
+		t.Fatalf("json contains failed: %v, sql: %s", err, builder.GetSql())
+	}
+	if count != 1 {
+		t.Errorf("Expected count to be %d, but got: %d", 1, count)
+	}
+
+	count, err = builder.Table("settings").WhereJSONLength("meta->tags", clause.OperatorGreaterThan, 1).Count()
+	if err != nil {
+		t.Fatalf("json length failed: %v, sql: %s", err, builder.GetSql())
+	}
+	if count != 1 {
+		t.Errorf("Expected count to be %d, but got: %d", 1, count)
+	}
+
+	_, err = builder.Table("settings").Where("id", clause.OperatorEqual, 2).Update(map[string]any{
+		"meta->address->city": "Denpasar",
+	})
+	if err != nil {
+		t.Fatalf("json update failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	var cities []map[string]any
+	err = builder.Table("settings").Select("id", "meta->address->city as city").WhereJSON("meta->address->city", clause.OperatorEqual, "Denpasar").Get(&cities)
+	if err != nil {
+		t.Fatalf("json select failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if len(cities) != 2 {
+		t.Fatalf("Expected %d rows, but got: %d", 2, len(cities))
+	}
+
+	if cities[1]["city"] != "Denpasar" {
+		t.Errorf("Expected city to be Denpasar, but got: %v", cities[1]["city"])
+	}
+}
+
+func TestWhereFullTextWithRelevance(t *testing.T) {
+	builder = New(dialect.NewMySQL(), db)
+	builder.Table("posts").
+		Select("id", "title").
+		Where("published", clause.OperatorEqual, true).
+		WhereFullText([]string{"title", "body"}, "golang").
+		SelectRelevance([]string{"title", "body"}, "golang", "score").
+		OrderByRelevance([]string{"title", "body"}, "golang").
+		Limit(10)
+
+	expected := "SELECT `id`,`title`,MATCH(`title`,`body`) AGAINST(? IN BOOLEAN MODE) AS `score` FROM `posts` WHERE `published` = ? AND MATCH(`title`,`body`) AGAINST(? IN BOOLEAN MODE) ORDER BY MATCH(`title`,`body`) AGAINST(? IN BOOLEAN MODE) DESC LIMIT ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 5 || args[0] != "golang" || args[1] != true || args[4] != int64(10) {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+
+	builder = New(dialect.NewPostgres(), db)
+	builder.Table("posts").
+		Where("published", clause.OperatorEqual, true).
+		WhereFullText([]string{"body"}, "golang", clause.FullTextOptions{Language: "english"})
+
+	expected = `SELECT * FROM "posts" WHERE "published" = $1 AND to_tsvector('english', "body") @@ plainto_tsquery('english', $2)`
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestRelevanceCombinedWithSelectAndOrderBy(t *testing.T) {
+	builder := New(dialect.NewMySQL(), db)
+	builder.Table("posts").
+		SelectRelevance([]string{"title"}, "golang", "score").
+		Select("id").
+		Where("published", clause.OperatorEqual, true).
+		OrderBy("id", clause.OrderDirectionASC).
+		OrderByRelevance([]string{"title"}, "golang")
+
+	expected := "SELECT `id`,MATCH(`title`) AGAINST(? IN BOOLEAN MODE) AS `score` FROM `posts` WHERE `published` = ? ORDER BY id ASC, MATCH(`title`) AGAINST(? IN BOOLEAN MODE) DESC"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 3 || args[0] != "golang" || args[1] != true || args[2] != "golang" {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestExecuteFullTextSQLite(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dba.Exec(`
+		CREATE VIRTUAL TABLE docs USING fts5(title, body);
+		INSERT INTO docs(title, body) VALUES('Go generics', 'type parameters in go');
+		INSERT INTO docs(title, body) VALUES('Rust traits', 'go is mentioned once');
+		INSERT INTO docs(title, body) VALUES('Cooking', 'pasta and sauce');
+	`)
+	if err != nil && strings.Contains(err.Error(), "no such module: fts5") {
+		t.Skip("the sqlite3 driver is built without FTS5, run with -tags sqlite_fts5")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	var docs []map[string]any
+	err = builder.Table("docs").
+		Select("title").
+		SelectRelevance([]string{"title", "body"}, "go", "score").
+		WhereFullText([]string{"title", "body"}, "go").
+		OrderByRelevance([]string{"title", "body"}, "go").
+		Get(&docs)
+	if err != nil {
+		t.Fatalf("search failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if len(docs) != 2 || docs[0]["title"] != "Go generics" {
+		t.Errorf("Expected the two go documents, best match first, got: %v", docs)
+	}
+
+	var titles []string
+	if err = builder.Table("docs").WhereFullText([]string{"body"}, "pasta").Pluck("title", &titles); err != nil {
+		t.Fatalf("search failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if len(titles) != 1 || titles[0] != "Cooking" {
+		t.Errorf("Expected the cooking document, got: %v", titles)
+	}
+}
+
+func TestWhereMap(t *testing.T) {
+	dialect := dialect.New("?", "`", "`")
+	builder = New(dialect, db)
+	builder.Table("users").WhereMap(map[string]any{
+		"username":   "alice",
+		"deleted_at": nil,
+		"age":        []int{20, 25},
+		"avatar":     []byte("raw"),
+	})
+
+	expected := "SELECT * FROM `users` WHERE `age` IN(?,?) AND `avatar` = ? AND `deleted_at` IS NULL AND `username` = ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if args := builder.GetArguments(); len(args) != 4 || args[0] != 20 || args[3] != "alice" {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestExecuteWhereStruct(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	dialect := dialect.New("?", "`", "`")
+	builder := New(dialect, dba)
+
+	var users []User
+	err = builder.Table("users").WhereStruct(User{Username: "alice", Age: 29}).Get(&users)
+	if err != nil {
+		t.Fatalf("query failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users` WHERE `age` = ? AND `username` = ?" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if len(users) != 1 || users[0].Email != "alice@example.com" {
+		t.Fatalf("Expected to find alice, got: %v", users)
+	}
+}
+
+func TestWhereMapEmptySlices(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db)
+	builder.Table("users").WhereMap(map[string]any{"id": []int64{}}).WhereNotIn("age", []any{})
+
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users` WHERE 1 = 0 AND 1 = 1" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if args := builder.GetArguments(); len(args) != 0 {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestExecuteWhereStructRejectsNonStructs(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	var users []User
+	if err = builder.Table("users").WhereStruct(map[string]any{"id": 1}).Get(&users); err == nil {
+		t.Error("Expected an error filtering by a map")
+	}
+
+	var nilUser *User
+	if _, err = builder.Table("users").WhereStruct(nilUser).Delete(); err == nil {
+		t.Error("Expected an error filtering by a nil pointer")
+	}
+
+	if _, err = builder.Table("users").WhereStruct(42).Update(map[string]any{"age": 1}); err == nil {
+		t.Error("Expected an error filtering by an int")
+	}
+
+	count, err := builder.Table("users").Where("age", clause.OperatorEqual, 1).Count()
+	if err != nil || count != 0 {
+		t.Errorf("Expected no row to be updated, got: %d, %v", count, err)
+	}
+
+	if count, err = builder.Table("users").Count(); err != nil || count != 10 {
+		t.Errorf("Expected no row to be deleted, got: %d, %v", count, err)
+	}
+}
+
+func TestTailClausesInAnyOrder(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db)
+	builder.Table("posts").
+		Where("published", clause.OperatorEqual, true).
+		Offset(20).
+		Limit(10).
+		OrderBy("id", clause.OrderDirectionDESC).
+		GroupBy("author_id").
+		OrderByRelevance([]string{"title"}, "golang")
+
+	expected := "SELECT * FROM `posts` WHERE `published` = ? GROUP BY `author_id` ORDER BY id DESC, bm25(`posts`) ASC LIMIT ? OFFSET ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 3 || args[0] != true || args[1] != int64(10) || args[2] != int64(20) {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+
+	builder = New(dialect.NewMySQL(), db)
+	builder.Table("posts").Limit(10).OrderByRelevance([]string{"title"}, "golang")
+
+	expected = "SELECT * FROM `posts` ORDER BY MATCH(`title`) AGAINST(? IN BOOLEAN MODE) DESC LIMIT ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if args := builder.GetArguments(); len(args) != 2 || args[0] != "golang" || args[1] != int64(10) {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestMultipleOrderBy(t *testing.T) {
+	dialect := dialect.New("?", "`", "`")
+	builder = New(dialect, db)
+	builder.Table("users").OrderBy("age", clause.OrderDirectionDESC).OrderBy(builder.QuoteColumn("users.id"), clause.OrderDirectionASC)
+
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users` ORDER BY age DESC, `users`.`id` ASC" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestExecuteUpsert(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dba.Exec(`
+		CREATE TABLE products(
+			id integer primary key,
+			sku TEXT UNIQUE,
+			price integer,
+			stock integer
+		);
+		INSERT INTO products values(null, 'A-1', 10, 5);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dialect := dialect.New("?", "`", "`")
+	builder := New(dialect, dba)
+
+	_, err = builder.Table("products").Upsert([]map[string]any{
+		{"sku": "A-1", "price": 12, "stock": 1},
+		{"sku": "B-1", "price": 20, "stock": 2},
+	}, []string{"sku"}, []string{"price"})
+	if err != nil {
+		t.Fatalf("upsert failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	_, err = builder.Table("products").UpsertWith([]map[string]any{
+		{"sku": "A-1", "price": 0, "stock": 3},
+	}, []string{"sku"}, map[string]any{
+		"stock": clause.Raw("`stock` + EXCLUDED.`stock`"),
+	})
+	if err != nil {
+		t.Fatalf("upsert with expressions failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	_, err = builder.Table("products").InsertOrIgnore([]map[string]any{
+		{"sku": "B-1", "price": 99, "stock": 99},
+	}, "sku")
+	if err != nil {
+		t.Fatalf("insert or ignore failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	var products []map[string]any
+	if err = builder.Table("products").OrderBy("sku", clause.OrderDirectionASC).Get(&products); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("Expected %d products, but got: %d", 2, len(products))
+	}
+
+	if products[0]["price"] != 12 || products[0]["stock"] != 8 {
+		t.Errorf("Unexpected first product: %v", products[0])
+	}
+
+	if products[1]["price"] != 20 {
+		t.Errorf("Expected the ignored insert to keep the price, got: %v", products[1])
+	}
+}
+
+func TestExecuteTruncate(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba, WithGlobalScope("adults", ColumnScope("age", 18, "users")))
+
+	if _, err = builder.Table("users").Truncate(); !errors.Is(err, ErrMissingWhere) {
+		t.Fatalf("Expected ErrMissingWhere without AllowFullTable, got: %v", err)
+	}
+
+	if _, err = builder.Table("users").AllowFullTable().Truncate(); err == nil {
+		t.Fatal("Expected an error when truncating a scoped table")
+	}
+
+	if _, err = builder.Table("users").WithoutGlobalScope().AllowFullTable().Truncate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "DELETE FROM `users`" {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	count, err := builder.Table("users").WithoutGlobalScope().Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Errorf("Expected no users left, got: %d", count)
+	}
+}
+
+func TestExecuteReplace(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	id, err := builder.Table("users").Replace(map[string]any{"id": 1, "username": "replaced", "email": "replaced@example.com", "age": 50})
+	if err != nil {
+		t.Fatalf("replace failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if id != 1 {
+		t.Errorf("Expected the replaced row id, got: %d", id)
+	}
+
+	type UserRow struct {
+		Id       int64  `db:"id"`
+		Username string `db:"username"`
+	}
+
+	res, err := builder.Table("users").ReplaceMany([]UserRow{{Id: 2, Username: "second"}, {Id: 20, Username: "new"}})
+	if err != nil {
+		t.Fatalf("replace many failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if affected, _ := res.RowsAffected(); affected != 2 {
+		t.Errorf("Expected 2 rows affected, got: %d", affected)
+	}
+
+	var user User
+	if err = builder.Table("users").Where("id", clause.OperatorEqual, 1).Get(&user); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Username != "replaced" || user.Age != 50 {
+		t.Errorf("Unexpected replaced user: %v", user)
+	}
+
+	count, err := builder.Table("users").Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 11 {
+		t.Errorf("Expected 11 users, got: %d", count)
+	}
+
+	pg := New(dialect.NewPostgres(), dba)
+	if _, err = pg.Table("users").Replace(map[string]any{"id": 1}); !errors.Is(err, ErrReplaceUnsupported) {
+		t.Errorf("Expected ErrReplaceUnsupported on PostgreSQL, got: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package sqlbuilder
+
+import "context"
+
+// BulkInsertOptions tunes BulkInsert. MaxParameters defaults to the dialect's limit on bound
+// parameters and BatchSize, when set, further caps the rows sent per statement, e.g. to stay
+// under MySQL's max_allowed_packet.
+type BulkInsertOptions struct {
+	MaxParameters int
+	BatchSize     int
+	Transaction   bool
+}
+
+// BulkInsert inserts the rows in as many statements as needed to respect the parameter limit
+// and returns the total number of rows affected. Rows are taken in the same forms as InsertMany.
+func (s *SQLBuilder) BulkInsert(data any, opts BulkInsertOptions) (int64, error) {
+	return s.BulkInsertContext(data, opts, context.Background())
+}
+
+// BulkInsertContext is BulkInsert stopping between batches once ctx is done. Unless the batches
+// run in a single transaction, the rows of the batches already sent stay inserted.
+func (s *SQLBuilder) BulkInsertContext(data any, opts BulkInsertOptions, ctx context.Context) (int64, error) {
+	rows, err := toRows(data)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err = s.insertRows(rows)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+
+	batchSize := opts.batchSize(s, len(rows[0]))
+
+	if !opts.Transaction || s.isTx {
+		return s.insertBatches(ctx, rows, batchSize)
+	}
+
+	transaction, err := s.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer transaction.Rollback()
+
+	builder := s.newBuilder()
+	builder.tx = transaction
+	builder.isTx = true
+	builder.tempTable = s.tempTable
+	builder.table = s.table
+
+	total, err := builder.insertBatches(ctx, rows, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (s *SQLBuilder) insertBatches(ctx context.Context, rows []map[string]any, batchSize int) (int64, error) {
+	var total int64
+
+	for start := 0; start < len(rows); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		end := min(start+batchSize, len(rows))
+
+		s.rawStatement = ""
+		s.Values = []any{}
+		s.resetDialectState()
+		s.buildInsert(rows[start:end])
+
+		res, err := s.ExecContext(ctx)
+		if err != nil {
+			return total, err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+	}
+
+	return total, nil
+}
+
+// batchSize returns the number of rows sent per statement for rows of the given width.
+func (o BulkInsertOptions) batchSize(s *SQLBuilder, columns int) int {
+	maxParameters := o.MaxParameters
+	if maxParameters <= 0 {
+		maxParameters = s.Dialect.GetName().MaxParameters()
+	}
+
+	size := max(maxParameters/max(columns, 1), 1)
+	if o.BatchSize > 0 && o.BatchSize < size {
+		size = o.BatchSize
+	}
+
+	return size
+}
@@ -0,0 +1,161 @@
+package sqlbuilder
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func bulkRows(n int) []map[string]any {
+	rows := make([]map[string]any, 0, n)
+	for i := 0; i < n; i++ {
+		rows = append(rows, map[string]any{
+			"username": fmt.Sprintf("user%d", i),
+			"email":    fmt.Sprintf("user%d@example.com", i),
+			"age":      20 + i%50,
+		})
+	}
+
+	return rows
+}
+
+func TestBulkInsertBatchSize(t *testing.T) {
+	tests := []struct {
+		dialect clause.SQLDialector
+		opts    BulkInsertOptions
+		columns int
+		want    int
+	}{
+		{dialect.New("?", "`", "`"), BulkInsertOptions{}, 3, 333},
+		{dialect.NewPostgres(), BulkInsertOptions{}, 5, 13107},
+		{dialect.NewMySQL(), BulkInsertOptions{BatchSize: 500}, 5, 500},
+		{dialect.NewMySQL(), BulkInsertOptions{MaxParameters: 10}, 3, 3},
+		{dialect.NewMySQL(), BulkInsertOptions{MaxParameters: 2}, 3, 1},
+	}
+
+	for _, tt := range tests {
+		builder := New(tt.dialect, db)
+		if got := tt.opts.batchSize(builder, tt.columns); got != tt.want {
+			t.Errorf("%s %+v: expected batches of %d rows, got: %d", tt.dialect.GetName(), tt.opts, tt.want, got)
+		}
+	}
+}
+
+func TestExecuteBulkInsert(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dba.SetMaxOpenConns(1)
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	builder := New(dialect.New("?", "`", "`"), dba, WithLogger(log.New(&buf, "", 0)))
+
+	total, err := builder.Table("users").BulkInsert(bulkRows(1000), BulkInsertOptions{Transaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 1000 {
+		t.Errorf("Expected 1000 rows affected, got: %d", total)
+	}
+
+	if statements := strings.Count(buf.String(), "INSERT INTO"); statements != 4 {
+		t.Errorf("Expected 4 insert statements, got: %d", statements)
+	}
+
+	count, err := builder.Table("users").Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1010 {
+		t.Errorf("Expected 1010 users, got: %d", count)
+	}
+}
+
+func TestExecuteBulkInsertRollsBack(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dba.SetMaxOpenConns(1)
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := bulkRows(20)
+	rows[15]["id"] = 1
+	for i, row := range rows {
+		if i != 15 {
+			row["id"] = 100 + i
+		}
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	total, err := builder.Table("users").BulkInsert(rows, BulkInsertOptions{BatchSize: 5, Transaction: true})
+	if err == nil {
+		t.Fatal("Expected the batch with a duplicate id to fail")
+	}
+
+	if total != 0 {
+		t.Errorf("Expected no rows reported after the rollback, got: %d", total)
+	}
+
+	count, err := builder.Table("users").Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 10 {
+		t.Errorf("Expected the seeded 10 users only, got: %d", count)
+	}
+
+	total, err = builder.Table("users").BulkInsert(rows, BulkInsertOptions{BatchSize: 5})
+	if err == nil {
+		t.Fatal("Expected the batch with a duplicate id to fail")
+	}
+
+	if total != 15 {
+		t.Errorf("Expected the 15 rows of the first batches, got: %d", total)
+	}
+}
+
+func TestBulkInsertCancelled(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	total, err := builder.Table("users").BulkInsertContext(bulkRows(10), BulkInsertOptions{BatchSize: 2}, ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+
+	if total != 0 {
+		t.Errorf("Expected no rows inserted, got: %d", total)
+	}
+}
@@ -0,0 +1,78 @@
+package clause
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+	"github.com/suryaherdiyanto/sqlbuilder/pkg"
+)
+
+// Expression is a SQL fragment used in place of a bound value.
+type Expression interface {
+	Parse(d SQLDialector) string
+	GetArguments() []any
+}
+
+// RawExpression is inlined as written, each ? is replaced with the dialect's placeholder.
+type RawExpression struct {
+	SQL    string
+	Values []any
+}
+
+// ColumnExpression refers to another column.
+type ColumnExpression struct {
+	Column string
+}
+
+// ExcludedExpression refers to the value an upsert tried to insert into Column.
+type ExcludedExpression struct {
+	Column string
+}
+
+func Raw(sql string, values ...any) RawExpression {
+	return RawExpression{SQL: sql, Values: values}
+}
+
+func Column(column string) ColumnExpression {
+	return ColumnExpression{Column: column}
+}
+
+func Excluded(column string) ExcludedExpression {
+	return ExcludedExpression{Column: column}
+}
+
+func (r RawExpression) Parse(d SQLDialector) string {
+	parts := strings.Split(r.SQL, "?")
+	stmt := parts[0]
+	for _, part := range parts[1:] {
+		stmt += d.GetDelimiter() + part
+	}
+
+	return stmt
+}
+
+func (r RawExpression) GetArguments() []any {
+	return r.Values
+}
+
+func (c ColumnExpression) Parse(d SQLDialector) string {
+	return pkg.ColumnSplitter(c.Column, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
+}
+
+func (c ColumnExpression) GetArguments() []any {
+	return []any{}
+}
+
+func (e ExcludedExpression) Parse(d SQLDialector) string {
+	column := fmt.Sprintf("%s%s%s", d.GetColumnQuoteLeft(), e.Column, d.GetColumnQuoteRight())
+	if d.GetName() == dialect.MySQL {
+		return fmt.Sprintf("VALUES(%s)", column)
+	}
+
+	return "EXCLUDED." + column
+}
+
+func (e ExcludedExpression) GetArguments() []any {
+	return []any{}
+}
@@ -0,0 +1,143 @@
+package clause
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+	"github.com/suryaherdiyanto/sqlbuilder/pkg"
+)
+
+type FullTextMode string
+
+const (
+	FullTextBoolean FullTextMode = "boolean"
+	FullTextNatural FullTextMode = "natural"
+)
+
+// FullTextOptions tunes the full-text predicates. Mode applies to MySQL and defaults to boolean mode,
+// Language is the PostgreSQL text search configuration, e.g. "english".
+type FullTextOptions struct {
+	Mode     FullTextMode
+	Language string
+}
+
+type WhereFullText struct {
+	Table   string
+	Columns []string
+	Query   string
+	Conj    Conjuction
+	Options FullTextOptions
+}
+
+type FullTextRelevance struct {
+	Table   string
+	Columns []string
+	Query   string
+	Options FullTextOptions
+}
+
+func (w WhereFullText) Parse(d SQLDialector) string {
+	switch d.GetName() {
+	case dialect.MySQL:
+		return mysqlMatch(d, w.Columns, w.Options)
+	case dialect.PostgreSQL:
+		return fmt.Sprintf("%s @@ %s", tsVector(d, w.Columns, w.Options), tsQuery(d, w.Options))
+	case dialect.SQLite:
+		if len(w.Columns) == 1 {
+			return fmt.Sprintf("%s MATCH %s", pkg.ColumnSplitter(w.Columns[0], d.GetColumnQuoteLeft(), d.GetColumnQuoteRight()), d.GetDelimiter())
+		}
+		return fmt.Sprintf("%s MATCH %s", w.Table, d.GetDelimiter())
+	default:
+		return ""
+	}
+}
+
+// GetArgument returns the bound search query. SQLite FTS5 restricts a search over several
+// columns with a column filter inside the query itself.
+func (w WhereFullText) GetArgument(d SQLDialector) any {
+	if d.GetName() == dialect.SQLite && len(w.Columns) > 1 {
+		return fmt.Sprintf("{%s} : (%s)", strings.Join(w.Columns, " "), w.Query)
+	}
+
+	return w.Query
+}
+
+// Parse renders the relevance score expression. On SQLite it is the FTS5 bm25 score,
+// where lower values are better matches.
+func (r FullTextRelevance) Parse(d SQLDialector) string {
+	switch d.GetName() {
+	case dialect.MySQL:
+		return mysqlMatch(d, r.Columns, r.Options)
+	case dialect.PostgreSQL:
+		return fmt.Sprintf("ts_rank(%s, %s)", tsVector(d, r.Columns, r.Options), tsQuery(d, r.Options))
+	case dialect.SQLite:
+		return fmt.Sprintf("bm25(%s)", r.Table)
+	default:
+		return ""
+	}
+}
+
+func (r FullTextRelevance) GetArguments(d SQLDialector) []any {
+	if d.GetName() == dialect.SQLite {
+		return []any{}
+	}
+
+	return []any{r.Query}
+}
+
+// Direction returns the ordering that puts the best matches first.
+func (r FullTextRelevance) Direction(d SQLDialector) OrderDirection {
+	if d.GetName() == dialect.SQLite {
+		return OrderDirectionASC
+	}
+
+	return OrderDirectionDESC
+}
+
+func mysqlMatch(d SQLDialector, columns []string, opts FullTextOptions) string {
+	mode := "IN BOOLEAN MODE"
+	if opts.Mode == FullTextNatural {
+		mode = "IN NATURAL LANGUAGE MODE"
+	}
+
+	return fmt.Sprintf("MATCH(%s) AGAINST(%s %s)", quoteColumns(d, columns, ","), d.GetDelimiter(), mode)
+}
+
+func tsVector(d SQLDialector, columns []string, opts FullTextOptions) string {
+	document := quoteColumns(d, columns, ",")
+	if len(columns) > 1 {
+		parts := make([]string, 0, len(columns))
+		for _, col := range columns {
+			parts = append(parts, fmt.Sprintf("coalesce(%s, '')", pkg.ColumnSplitter(col, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())))
+		}
+		document = strings.Join(parts, " || ' ' || ")
+	}
+
+	if opts.Language != "" {
+		return fmt.Sprintf("to_tsvector(%s, %s)", languageLiteral(opts.Language), document)
+	}
+
+	return fmt.Sprintf("to_tsvector(%s)", document)
+}
+
+func tsQuery(d SQLDialector, opts FullTextOptions) string {
+	if opts.Language != "" {
+		return fmt.Sprintf("plainto_tsquery(%s, %s)", languageLiteral(opts.Language), d.GetDelimiter())
+	}
+
+	return fmt.Sprintf("plainto_tsquery(%s)", d.GetDelimiter())
+}
+
+func languageLiteral(language string) string {
+	return "'" + strings.ReplaceAll(language, "'", "''") + "'"
+}
+
+func quoteColumns(d SQLDialector, columns []string, sep string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, col := range columns {
+		quoted = append(quoted, pkg.ColumnSplitter(col, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight()))
+	}
+
+	return strings.Join(quoted, sep)
+}
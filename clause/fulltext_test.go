@@ -0,0 +1,73 @@
+package clause
+
+import (
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestWhereFullTextParsing(t *testing.T) {
+	where := WhereFullText{Table: "`posts`", Columns: []string{"title", "body"}, Query: "golang"}
+
+	stmt := where.Parse(dialect.NewMySQL())
+	expected := "MATCH(`title`,`body`) AGAINST(? IN BOOLEAN MODE)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	where.Options = FullTextOptions{Mode: FullTextNatural}
+	stmt = where.Parse(dialect.NewMySQL())
+	expected = "MATCH(`title`,`body`) AGAINST(? IN NATURAL LANGUAGE MODE)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.New("?", "`", "`"))
+	expected = "`posts` MATCH ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if arg := where.GetArgument(dialect.New("?", "`", "`")); arg != "{title body} : (golang)" {
+		t.Errorf("Unexpected SQLite argument, got: %v", arg)
+	}
+}
+
+func TestWhereFullTextParsingPG(t *testing.T) {
+	where := WhereFullText{Table: `"posts"`, Columns: []string{"title"}, Query: "golang"}
+
+	stmt := where.Parse(dialect.NewPostgres())
+	expected := `to_tsvector("title") @@ plainto_tsquery($1)`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	where.Columns = []string{"title", "body"}
+	where.Options = FullTextOptions{Language: "english"}
+	stmt = where.Parse(dialect.NewPostgres())
+	expected = `to_tsvector('english', coalesce("title", '') || ' ' || coalesce("body", '')) @@ plainto_tsquery('english', $1)`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestFullTextRelevanceParsing(t *testing.T) {
+	relevance := FullTextRelevance{Table: "`posts`", Columns: []string{"title"}, Query: "golang"}
+
+	stmt := relevance.Parse(dialect.NewPostgres())
+	expected := `ts_rank(to_tsvector("title"), plainto_tsquery($1))`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	sqlite := dialect.New("?", "`", "`")
+	stmt = relevance.Parse(sqlite)
+	expected = "bm25(`posts`)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if len(relevance.GetArguments(sqlite)) != 0 || relevance.Direction(sqlite) != OrderDirectionASC {
+		t.Errorf("Expected SQLite relevance to have no arguments and ascending order")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 )
 
+// Insert writes Rows, whose values are bound or, for Expressions such as Raw("DEFAULT"), inlined.
 type Insert struct {
 	Table  string
 	Rows   []map[string]any
@@ -34,8 +35,15 @@ func (in Insert) Parse(d SQLDialector) (string, Insert) {
 	insertValues := ""
 	for i := range len(in.Rows) {
 		rowValues := ""
-		for idx := range keys {
-			rowValues += d.GetDelimiter()
+		for idx, k := range keys {
+			if expr, ok := in.Rows[i][k].(Expression); ok {
+				rowValues += expr.Parse(d)
+				in.Values = append(in.Values, expr.GetArguments()...)
+			} else {
+				rowValues += d.GetDelimiter()
+				in.Values = append(in.Values, in.Rows[i][k])
+			}
+
 			if idx < len(keys)-1 {
 				rowValues += ","
 			}
@@ -45,11 +53,30 @@ func (in Insert) Parse(d SQLDialector) (string, Insert) {
 		if i < len(in.Rows)-1 {
 			insertValues += ","
 		}
-
-		for _, k := range keys {
-			in.Values = append(in.Values, in.Rows[i][k])
-		}
 	}
 
 	return fmt.Sprintf("INSERT INTO %s(%s) VALUES%s", in.Table, columns, insertValues), in
 }
+
+// InsertSelect copies the rows selected by Query, a rendered SELECT statement, into Table.
+type InsertSelect struct {
+	Table   string
+	Columns []string
+	Query   string
+}
+
+func (in InsertSelect) Parse(d SQLDialector) string {
+	return fmt.Sprintf("INSERT INTO %s(%s) %s", in.Table, quoteColumns(d, in.Columns, ","), in.Query)
+}
+
+// Replace is an insert that first deletes the rows conflicting on a unique key, supported by MySQL and SQLite.
+type Replace struct {
+	Insert
+}
+
+func (r Replace) Parse(d SQLDialector) (string, Replace) {
+	stmt, insert := r.Insert.Parse(d)
+	r.Insert = insert
+
+	return strings.Replace(stmt, "INSERT INTO", "REPLACE INTO", 1), r
+}
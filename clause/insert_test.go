@@ -104,3 +104,26 @@ func TestInsertStatementMultiplePG(t *testing.T) {
 		t.Errorf("Expected: %s, but got: %s", expected, stmt)
 	}
 }
+
+func TestReplaceStatement(t *testing.T) {
+	statement := Replace{
+		Insert: Insert{
+			Table: "`settings`",
+			Rows: []map[string]any{
+				{"key": "theme", "value": "dark"},
+				{"key": "lang", "value": "en"},
+			},
+		},
+	}
+
+	stmt, replace := statement.Parse(dialect.NewMySQL())
+	expected := "REPLACE INTO `settings`(`key`,`value`) VALUES(?,?),(?,?)"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if len(replace.Values) != 4 || replace.Values[0] != "theme" || replace.Values[3] != "en" {
+		t.Errorf("Unexpected values, got: %v", replace.Values)
+	}
+}
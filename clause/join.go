@@ -17,6 +17,7 @@ type Join struct {
 	Type        JoinType
 	SecondTable string
 	On          JoinON
+	Conditions  []WhereParser
 }
 
 type CrossJoin struct {
@@ -24,11 +25,22 @@ type CrossJoin struct {
 }
 
 func (j Join) Parse(d SQLDialector) string {
+	rightTable := d.GetColumnQuoteLeft() + j.SecondTable + d.GetColumnQuoteRight()
+
+	return fmt.Sprintf("%s %s ON %s", strings.ToUpper(string(j.Type)), rightTable, j.ParseOn(d))
+}
+
+// ParseOn renders the join condition alone, as used when the joined table is listed in FROM or USING.
+func (j Join) ParseOn(d SQLDialector) string {
 	leftField := pkg.ColumnSplitter(j.On.LeftField, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
 	rightField := pkg.ColumnSplitter(j.On.RightField, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
-	rightTable := d.GetColumnQuoteLeft() + j.SecondTable + d.GetColumnQuoteRight()
 
-	return fmt.Sprintf("%s %s ON %s %s %s", strings.ToUpper(string(j.Type)), rightTable, leftField, j.On.Operator, rightField)
+	stmt := fmt.Sprintf("%s %s %s", leftField, j.On.Operator, rightField)
+	for _, condition := range j.Conditions {
+		stmt += " AND " + condition.Parse(d)
+	}
+
+	return stmt
 }
 
 func (j CrossJoin) Parse(d SQLDialector) string {
@@ -0,0 +1,233 @@
+package clause
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+	"github.com/suryaherdiyanto/sqlbuilder/pkg"
+)
+
+const JSONPathSeparator = "->"
+
+// IsJSONPath reports whether the field uses the arrow syntax, e.g. "meta->address->city".
+func IsJSONPath(field string) bool {
+	return strings.Contains(field, JSONPathSeparator)
+}
+
+// SplitJSONPath splits "meta->address->city" into the column "meta" and the path ["address", "city"].
+func SplitJSONPath(field string) (string, []string) {
+	parts := strings.Split(field, JSONPathSeparator)
+	column := strings.TrimSpace(parts[0])
+
+	path := make([]string, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		path = append(path, strings.Trim(strings.TrimSpace(p), `'"`))
+	}
+
+	return column, path
+}
+
+func jsonColumn(d SQLDialector, column string) string {
+	return pkg.ColumnSplitter(column, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
+}
+
+func isJSONIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// isJSONKey reports whether a path segment can be written without quotes.
+func isJSONKey(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	for i, r := range segment {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// quoteJSONKey double quotes a path segment, escaping its backslashes and double quotes.
+func quoteJSONKey(segment string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(segment) + `"`
+}
+
+// sqlString renders s as a single quoted SQL string literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// jsonPathLiteral renders the path for MySQL and SQLite JSON functions, e.g. '$.address.city', '$.tags[0]'
+// or '$."first name"'.
+func jsonPathLiteral(path []string) string {
+	p := "$"
+	for _, segment := range path {
+		switch {
+		case isJSONIndex(segment):
+			p += "[" + segment + "]"
+		case isJSONKey(segment):
+			p += "." + segment
+		default:
+			p += "." + quoteJSONKey(segment)
+		}
+	}
+
+	return sqlString(p)
+}
+
+// jsonPathArray renders the path for PostgreSQL jsonb_set, e.g. '{address,city}'.
+func jsonPathArray(path []string) string {
+	elements := make([]string, 0, len(path))
+	for _, segment := range path {
+		if isJSONIndex(segment) || isJSONKey(segment) {
+			elements = append(elements, segment)
+			continue
+		}
+		elements = append(elements, quoteJSONKey(segment))
+	}
+
+	return sqlString("{" + strings.Join(elements, ",") + "}")
+}
+
+// jsonArrows renders the PostgreSQL arrow operators for the path, using ->> for the last segment when unquote is set.
+func jsonArrows(column string, path []string, unquote bool) string {
+	stmt := column
+	for i, segment := range path {
+		arrow := "->"
+		if unquote && i == len(path)-1 {
+			arrow = "->>"
+		}
+
+		if isJSONIndex(segment) {
+			stmt += arrow + segment
+			continue
+		}
+		stmt += arrow + sqlString(segment)
+	}
+
+	return stmt
+}
+
+// JSONExtract renders the expression reading the value at the field's JSON path.
+// When unquote is set the value is returned as text rather than as a JSON document.
+func JSONExtract(d SQLDialector, field string, unquote bool) string {
+	column, path := SplitJSONPath(field)
+	col := jsonColumn(d, column)
+
+	if len(path) == 0 {
+		return col
+	}
+
+	switch d.GetName() {
+	case dialect.MySQL:
+		if unquote {
+			return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", col, jsonPathLiteral(path))
+		}
+		return fmt.Sprintf("JSON_EXTRACT(%s, %s)", col, jsonPathLiteral(path))
+	case dialect.PostgreSQL:
+		return jsonArrows(col, path, unquote)
+	case dialect.SQLite:
+		return fmt.Sprintf("json_extract(%s, %s)", col, jsonPathLiteral(path))
+	default:
+		return ""
+	}
+}
+
+// JSONSelect renders a selected JSON path aliased to its last segment, or to the alias given with "AS".
+func JSONSelect(d SQLDialector, column string) string {
+	alias := ""
+	lower := strings.ToLower(column)
+	if idx := strings.Index(lower, " as "); idx != -1 {
+		alias = strings.TrimSpace(column[idx+4:])
+		column = strings.TrimSpace(column[:idx])
+	}
+
+	_, path := SplitJSONPath(column)
+	if alias == "" && len(path) > 0 {
+		alias = path[len(path)-1]
+	}
+
+	return fmt.Sprintf("%s AS %s%s%s", JSONExtract(d, column, true), d.GetColumnQuoteLeft(), alias, d.GetColumnQuoteRight())
+}
+
+// JSONSetFrom renders the assignment setting the column to the document base with the given JSON paths
+// updated, base defaults to the current value of the column.
+func JSONSetFrom(d SQLDialector, column string, base string, fields []string, delimiters []string) string {
+	col := jsonColumn(d, column)
+	if base == "" {
+		base = col
+	}
+
+	switch d.GetName() {
+	case dialect.MySQL, dialect.SQLite:
+		fn := "JSON_SET"
+		if d.GetName() == dialect.SQLite {
+			fn = "json_set"
+		}
+
+		args := base
+		for i, field := range fields {
+			_, path := SplitJSONPath(field)
+			args += fmt.Sprintf(", %s, %s", jsonPathLiteral(path), delimiters[i])
+		}
+
+		return fmt.Sprintf("%s = %s(%s)", col, fn, args)
+	case dialect.PostgreSQL:
+		expr := base + "::jsonb"
+		for i, field := range fields {
+			_, path := SplitJSONPath(field)
+			expr = fmt.Sprintf("jsonb_set(%s, %s, %s::jsonb)", expr, jsonPathArray(path), delimiters[i])
+		}
+
+		return fmt.Sprintf("%s = %s", col, expr)
+	default:
+		return ""
+	}
+}
+
+// JSONCast returns the PostgreSQL type an extracted text value is cast to before being compared with value,
+// numeric for numbers and boolean for booleans, "" when it is compared as text.
+func JSONCast(value any) string {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return "numeric"
+	case bool:
+		return "boolean"
+	default:
+		return ""
+	}
+}
+
+// JSONValue prepares a value bound against a JSON document. PostgreSQL compares and sets jsonb,
+// so the value is encoded, MySQL and SQLite bind scalars as they are.
+func JSONValue(d SQLDialector, value any) any {
+	if d.GetName() != dialect.PostgreSQL {
+		return value
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	return string(encoded)
+}
+
+// jsonDocument prepares a whole document bound as the base of JSON paths: text is taken as encoded
+// JSON already, other values are encoded through JSONValue.
+func jsonDocument(d SQLDialector, value any) any {
+	switch value.(type) {
+	case string, []byte, json.RawMessage:
+		return value
+	}
+
+	return JSONValue(d, value)
+}
@@ -24,6 +24,11 @@ func (s Select) Parse(d SQLDialector) (string, Select) {
 			continue
 		}
 
+		if IsJSONPath(col) {
+			columns = append(columns, JSONSelect(d, col))
+			continue
+		}
+
 		columns = append(columns, pkg.ColumnSplitter(col, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight()))
 	}
 
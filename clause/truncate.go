@@ -0,0 +1,38 @@
+package clause
+
+import (
+	"fmt"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+// TruncateOptions apply to PostgreSQL only, RestartIdentity resets the table's sequences
+// and Cascade truncates the tables referencing it as well.
+type TruncateOptions struct {
+	RestartIdentity bool
+	Cascade         bool
+}
+
+type Truncate struct {
+	Table   string
+	Options TruncateOptions
+}
+
+// Parse renders TRUNCATE TABLE, or DELETE FROM on SQLite which has no TRUNCATE statement.
+func (t Truncate) Parse(d SQLDialector) string {
+	switch d.GetName() {
+	case dialect.PostgreSQL:
+		stmt := fmt.Sprintf("TRUNCATE TABLE %s", t.Table)
+		if t.Options.RestartIdentity {
+			stmt += " RESTART IDENTITY"
+		}
+		if t.Options.Cascade {
+			stmt += " CASCADE"
+		}
+		return stmt
+	case dialect.MySQL:
+		return fmt.Sprintf("TRUNCATE TABLE %s", t.Table)
+	default:
+		return fmt.Sprintf("DELETE FROM %s", t.Table)
+	}
+}
@@ -0,0 +1,27 @@
+package clause
+
+import (
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestTruncateStatement(t *testing.T) {
+	tests := []struct {
+		dialect  SQLDialector
+		options  TruncateOptions
+		expected string
+	}{
+		{dialect.NewMySQL(), TruncateOptions{Cascade: true}, "TRUNCATE TABLE staging"},
+		{dialect.NewPostgres(), TruncateOptions{}, "TRUNCATE TABLE staging"},
+		{dialect.NewPostgres(), TruncateOptions{RestartIdentity: true, Cascade: true}, "TRUNCATE TABLE staging RESTART IDENTITY CASCADE"},
+		{dialect.New("?", "`", "`"), TruncateOptions{RestartIdentity: true}, "DELETE FROM staging"},
+	}
+
+	for _, tt := range tests {
+		stmt := Truncate{Table: "staging", Options: tt.options}.Parse(tt.dialect)
+		if stmt != tt.expected {
+			t.Errorf("Expected: %s, but got: %s", tt.expected, stmt)
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"strings"
 
 	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+	"github.com/suryaherdiyanto/sqlbuilder/pkg"
 )
 
+// Update sets the columns of Rows to bound values, or to Expressions such as Raw("views + ?", 1) or Column("other").
 type Update struct {
 	Table  string
 	Rows   map[string]any
@@ -23,16 +25,69 @@ func (u Update) Parse(d SQLDialector, i int) (string, Update) {
 	}
 	slices.Sort(keys)
 
-	for j, k := range keys {
+	j := 0
+	nextDelimiter := func() string {
 		delimiter := d.GetDelimiter()
 		if d.GetName() == dialect.PostgreSQL {
 			delimiter = fmt.Sprintf("$%d", i+j)
 		}
+		j++
 
-		stmt += fmt.Sprintf("%s%s%s = %s, ", d.GetColumnQuoteLeft(), k, d.GetColumnQuoteRight(), delimiter)
-		if val, ok := u.Rows[k]; ok {
-			u.Values = append(u.Values, val)
+		return delimiter
+	}
+
+	// the paths of a column are set in a single assignment, starting from the column's new value if it is set too
+	columns := []string{}
+	paths := map[string][]string{}
+	for _, k := range keys {
+		column := k
+		if IsJSONPath(k) {
+			column, _ = SplitJSONPath(k)
+		}
+
+		if _, ok := paths[column]; !ok {
+			columns = append(columns, column)
+			paths[column] = []string{}
+		}
+		if IsJSONPath(k) {
+			paths[column] = append(paths[column], k)
+		}
+	}
+
+	for _, k := range columns {
+		value, set := u.Rows[k]
+
+		if fields := paths[k]; len(fields) > 0 {
+			base := ""
+			if set {
+				if expr, ok := value.(Expression); ok {
+					base = expr.Parse(sequencedDialect{SQLDialector: d, next: nextDelimiter})
+					u.Values = append(u.Values, expr.GetArguments()...)
+				} else {
+					base = nextDelimiter()
+					u.Values = append(u.Values, jsonDocument(d, value))
+				}
+			}
+
+			delimiters := make([]string, 0, len(fields))
+			for _, f := range fields {
+				delimiters = append(delimiters, nextDelimiter())
+				u.Values = append(u.Values, JSONValue(d, u.Rows[f]))
+			}
+
+			stmt += JSONSetFrom(d, k, base, fields, delimiters) + ", "
+			continue
 		}
+
+		column := pkg.ColumnSplitter(k, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
+		if expr, ok := value.(Expression); ok {
+			stmt += fmt.Sprintf("%s = %s, ", column, expr.Parse(sequencedDialect{SQLDialector: d, next: nextDelimiter}))
+			u.Values = append(u.Values, expr.GetArguments()...)
+			continue
+		}
+
+		stmt += fmt.Sprintf("%s = %s, ", column, nextDelimiter())
+		u.Values = append(u.Values, value)
 	}
 
 	stmt = strings.TrimRight(stmt, ", ")
@@ -43,3 +98,13 @@ func (u Update) Parse(d SQLDialector, i int) (string, Update) {
 func (u Update) GetArguments() []any {
 	return u.Values
 }
+
+// sequencedDialect hands out the placeholders of an update's own sequence to the expressions it renders.
+type sequencedDialect struct {
+	SQLDialector
+	next func() string
+}
+
+func (s sequencedDialect) GetDelimiter() string {
+	return s.next()
+}
@@ -0,0 +1,115 @@
+package clause
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+	"github.com/suryaherdiyanto/sqlbuilder/pkg"
+)
+
+var ErrNoCaseColumns = errors.New("clause: UpdateCase row has no column besides its key")
+
+// UpdateCase updates many rows, identified by their Key column, in a single statement. Each column of Rows
+// is set through a CASE on the key, rows without the column keep their value. Set holds the columns given
+// the same value in every row, unless a row sets them itself.
+type UpdateCase struct {
+	Table  string
+	Key    string
+	Rows   []map[string]any
+	Set    map[string]any
+	Values []any
+}
+
+// Parse renders the statement up to the key's IN condition, PostgreSQL placeholders are numbered from i.
+// It returns ErrNoCaseColumns for a row without any column besides its key.
+func (u UpdateCase) Parse(d SQLDialector, i int) (string, UpdateCase, error) {
+	j := 0
+	nextDelimiter := func() string {
+		delimiter := d.GetDelimiter()
+		if d.GetName() == dialect.PostgreSQL {
+			delimiter = fmt.Sprintf("$%d", i+j)
+		}
+		j++
+
+		return delimiter
+	}
+	sequenced := sequencedDialect{SQLDialector: d, next: nextDelimiter}
+
+	caseColumns := map[string]bool{}
+	for n, row := range u.Rows {
+		_, keyed := row[u.Key]
+		if len(row) == 0 || (keyed && len(row) == 1) {
+			return "", u, fmt.Errorf("%w: row %d", ErrNoCaseColumns, n)
+		}
+
+		for column := range row {
+			if column != u.Key {
+				caseColumns[column] = true
+			}
+		}
+	}
+
+	columns := make([]string, 0, len(caseColumns)+len(u.Set))
+	for column := range caseColumns {
+		columns = append(columns, column)
+	}
+	for column := range u.Set {
+		if !caseColumns[column] {
+			columns = append(columns, column)
+		}
+	}
+	slices.Sort(columns)
+
+	key := pkg.ColumnSplitter(u.Key, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
+	assignments := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		col := pkg.ColumnSplitter(column, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
+
+		if !caseColumns[column] {
+			assignments = append(assignments, fmt.Sprintf("%s = %s", col, u.value(sequenced, u.Set[column])))
+			continue
+		}
+
+		whens := ""
+		for _, row := range u.Rows {
+			value, ok := row[column]
+			if !ok {
+				continue
+			}
+
+			when := nextDelimiter()
+			u.Values = append(u.Values, row[u.Key])
+			whens += fmt.Sprintf(" WHEN %s THEN %s", when, u.value(sequenced, value))
+		}
+
+		assignments = append(assignments, fmt.Sprintf("%s = CASE %s%s ELSE %s END", col, key, whens, col))
+	}
+
+	keys := make([]string, 0, len(u.Rows))
+	for _, row := range u.Rows {
+		keys = append(keys, nextDelimiter())
+		u.Values = append(u.Values, row[u.Key])
+	}
+
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s IN(%s)", u.Table, strings.Join(assignments, ", "), key, strings.Join(keys, ",")), u, nil
+}
+
+// value renders a bound value, or an expression, and records its arguments.
+func (u *UpdateCase) value(d SQLDialector, value any) string {
+	if expr, ok := value.(Expression); ok {
+		stmt := expr.Parse(d)
+		u.Values = append(u.Values, expr.GetArguments()...)
+		return stmt
+	}
+
+	u.Values = append(u.Values, value)
+	return d.GetDelimiter()
+}
+
+func (u UpdateCase) GetArguments() []any {
+	return u.Values
+}
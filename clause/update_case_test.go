@@ -0,0 +1,80 @@
+package clause
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestUpdateCaseStatement(t *testing.T) {
+	statement := UpdateCase{
+		Table: "`products`",
+		Key:   "sku",
+		Rows: []map[string]any{
+			{"sku": "A1", "price": 10, "stock": 3},
+			{"sku": "B2", "price": 20},
+		},
+	}
+
+	stmt, update, err := statement.Parse(dialect.NewMySQL(), 1)
+	expected := "UPDATE `products` SET `price` = CASE `sku` WHEN ? THEN ? WHEN ? THEN ? ELSE `price` END, `stock` = CASE `sku` WHEN ? THEN ? ELSE `stock` END WHERE `sku` IN(?,?)"
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	values := update.GetArguments()
+	want := []any{"A1", 10, "B2", 20, "A1", 3, "A1", "B2"}
+	if len(values) != len(want) {
+		t.Fatalf("Unexpected values, got: %v", values)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Fatalf("Unexpected values, got: %v", values)
+		}
+	}
+}
+
+func TestUpdateCaseStatementPG(t *testing.T) {
+	statement := UpdateCase{
+		Table: `"products"`,
+		Key:   "id",
+		Rows: []map[string]any{
+			{"id": 1, "stock": Raw(`"stock" + ?`, 5)},
+			{"id": 2, "stock": 0},
+		},
+		Set: map[string]any{"updated_at": "now"},
+	}
+
+	stmt, update, err := statement.Parse(dialect.NewPostgres(), 3)
+	expected := `UPDATE "products" SET "stock" = CASE "id" WHEN $3 THEN "stock" + $4 WHEN $5 THEN $6 ELSE "stock" END, "updated_at" = $7 WHERE "id" IN($8,$9)`
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if values := update.GetArguments(); len(values) != 7 || values[1] != 5 || values[4] != "now" {
+		t.Errorf("Unexpected values, got: %v", values)
+	}
+}
+
+func TestUpdateCaseRejectsKeyOnlyRows(t *testing.T) {
+	statement := UpdateCase{
+		Table: "`products`",
+		Key:   "sku",
+		Rows:  []map[string]any{{"sku": "A1"}},
+	}
+
+	if _, _, err := statement.Parse(dialect.NewMySQL(), 1); !errors.Is(err, ErrNoCaseColumns) {
+		t.Errorf("Expected ErrNoCaseColumns, got: %v", err)
+	}
+}
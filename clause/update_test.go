@@ -54,3 +54,28 @@ func TestUpdateStatementPG(t *testing.T) {
 		t.Errorf("Expected: %s, but got: %s", expected, stmt)
 	}
 }
+
+func TestUpdateStatementWithExpressions(t *testing.T) {
+	dialect := dialect.NewPostgres()
+	statement := Update{
+		Table: "posts",
+		Rows: map[string]any{
+			"views":      Raw(`"views" + ?`, 1),
+			"title":      "test",
+			"updated_by": Column("created_by"),
+			"score":      Raw(`"score" * ? + ?`, 2, 3),
+		},
+	}
+
+	stmt, update := statement.Parse(dialect, 2)
+	expected := `UPDATE posts SET "score" = "score" * $2 + $3, "title" = $4, "updated_by" = "created_by", "views" = "views" + $5`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	values := update.GetArguments()
+	if len(values) != 4 || values[0] != 2 || values[1] != 3 || values[2] != "test" || values[3] != 1 {
+		t.Errorf("Unexpected values, got: %v", values)
+	}
+}
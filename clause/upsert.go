@@ -0,0 +1,77 @@
+package clause
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+// Upsert is an insert that updates, or ignores, the rows conflicting with existing ones.
+// UpdateColumns take the inserted value, Updates may hold bound values or Expressions.
+// MySQL ignores ConflictColumns and relies on the table's unique keys.
+type Upsert struct {
+	Insert
+	ConflictColumns []string
+	UpdateColumns   []string
+	Updates         map[string]any
+	Ignore          bool
+}
+
+func (u Upsert) Parse(d SQLDialector) (string, Upsert) {
+	stmt, insert := u.Insert.Parse(d)
+	u.Insert = insert
+
+	if u.Ignore && d.GetName() == dialect.MySQL {
+		return strings.Replace(stmt, "INSERT INTO", "INSERT IGNORE INTO", 1), u
+	}
+
+	assignments := []string{}
+	for _, column := range u.UpdateColumns {
+		assignments = append(assignments, fmt.Sprintf("%s = %s", u.quote(d, column), Excluded(column).Parse(d)))
+	}
+
+	keys := make([]string, 0, len(u.Updates))
+	for k := range u.Updates {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if expr, ok := u.Updates[k].(Expression); ok {
+			assignments = append(assignments, fmt.Sprintf("%s = %s", u.quote(d, k), expr.Parse(d)))
+			u.Values = append(u.Values, expr.GetArguments()...)
+			continue
+		}
+
+		assignments = append(assignments, fmt.Sprintf("%s = %s", u.quote(d, k), d.GetDelimiter()))
+		u.Values = append(u.Values, u.Updates[k])
+	}
+
+	if d.GetName() == dialect.MySQL {
+		if len(assignments) == 0 {
+			return strings.Replace(stmt, "INSERT INTO", "INSERT IGNORE INTO", 1), u
+		}
+		return fmt.Sprintf("%s ON DUPLICATE KEY UPDATE %s", stmt, strings.Join(assignments, ", ")), u
+	}
+
+	target := ""
+	if len(u.ConflictColumns) > 0 {
+		columns := make([]string, 0, len(u.ConflictColumns))
+		for _, column := range u.ConflictColumns {
+			columns = append(columns, u.quote(d, column))
+		}
+		target = fmt.Sprintf(" (%s)", strings.Join(columns, ","))
+	}
+
+	if u.Ignore || len(assignments) == 0 {
+		return fmt.Sprintf("%s ON CONFLICT%s DO NOTHING", stmt, target), u
+	}
+
+	return fmt.Sprintf("%s ON CONFLICT%s DO UPDATE SET %s", stmt, target, strings.Join(assignments, ", ")), u
+}
+
+func (u Upsert) quote(d SQLDialector, column string) string {
+	return fmt.Sprintf("%s%s%s", d.GetColumnQuoteLeft(), column, d.GetColumnQuoteRight())
+}
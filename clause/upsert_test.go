@@ -0,0 +1,77 @@
+package clause
+
+import (
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func upsertRows() Insert {
+	return Insert{
+		Table: "products",
+		Rows: []map[string]any{
+			{"sku": "A-1", "price": 10, "stock": 3},
+		},
+	}
+}
+
+func TestUpsertStatement(t *testing.T) {
+	statement := Upsert{
+		Insert:          upsertRows(),
+		ConflictColumns: []string{"sku"},
+		UpdateColumns:   []string{"price", "stock"},
+	}
+
+	stmt, _ := statement.Parse(dialect.New("?", "`", "`"))
+	expected := "INSERT INTO products(`price`,`sku`,`stock`) VALUES(?,?,?) ON CONFLICT (`sku`) DO UPDATE SET `price` = EXCLUDED.`price`, `stock` = EXCLUDED.`stock`"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt, _ = statement.Parse(dialect.NewMySQL())
+	expected = "INSERT INTO products(`price`,`sku`,`stock`) VALUES(?,?,?) ON DUPLICATE KEY UPDATE `price` = VALUES(`price`), `stock` = VALUES(`stock`)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestUpsertStatementWithExpressionsPG(t *testing.T) {
+	statement := Upsert{
+		Insert:          upsertRows(),
+		ConflictColumns: []string{"sku"},
+		Updates: map[string]any{
+			"stock": Raw(`"products"."stock" + EXCLUDED."stock" + ?`, 1),
+			"price": 12,
+		},
+	}
+
+	stmt, upsert := statement.Parse(dialect.NewPostgres())
+	expected := `INSERT INTO products("price","sku","stock") VALUES($1,$2,$3) ON CONFLICT ("sku") DO UPDATE SET "price" = $4, "stock" = "products"."stock" + EXCLUDED."stock" + $5`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if len(upsert.Values) != 5 || upsert.Values[3] != 12 || upsert.Values[4] != 1 {
+		t.Errorf("Unexpected values: %v", upsert.Values)
+	}
+}
+
+func TestUpsertIgnoreStatement(t *testing.T) {
+	statement := Upsert{
+		Insert:          upsertRows(),
+		ConflictColumns: []string{"sku"},
+		Ignore:          true,
+	}
+
+	stmt, _ := statement.Parse(dialect.NewMySQL())
+	expected := "INSERT IGNORE INTO products(`price`,`sku`,`stock`) VALUES(?,?,?)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt, _ = statement.Parse(dialect.NewPostgres())
+	expected = `INSERT INTO products("price","sku","stock") VALUES($1,$2,$3) ON CONFLICT ("sku") DO NOTHING`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
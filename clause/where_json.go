@@ -0,0 +1,96 @@
+package clause
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+type WhereJSON struct {
+	Field string
+	Op    Operator
+	Conj  Conjuction
+	Value any
+}
+
+type WhereJSONContains struct {
+	Field string
+	Conj  Conjuction
+	Value any
+}
+
+type WhereJSONLength struct {
+	Field string
+	Op    Operator
+	Conj  Conjuction
+	Value any
+}
+
+// Parse renders the comparison, on PostgreSQL the extracted text is cast when compared with a number or a boolean.
+func (w WhereJSON) Parse(d SQLDialector) string {
+	extract := JSONExtract(d, w.Field, true)
+	if cast := JSONCast(w.Value); cast != "" && d.GetName() == dialect.PostgreSQL && IsJSONPath(w.Field) {
+		extract = fmt.Sprintf("(%s)::%s", extract, cast)
+	}
+
+	return fmt.Sprintf("%s %s %s", extract, w.Op, d.GetDelimiter())
+}
+
+func (w WhereJSONContains) Parse(d SQLDialector) string {
+	column, path := SplitJSONPath(w.Field)
+	col := jsonColumn(d, column)
+
+	switch d.GetName() {
+	case dialect.MySQL:
+		if len(path) == 0 {
+			return fmt.Sprintf("JSON_CONTAINS(%s, %s)", col, d.GetDelimiter())
+		}
+		return fmt.Sprintf("JSON_CONTAINS(%s, %s, %s)", col, d.GetDelimiter(), jsonPathLiteral(path))
+	case dialect.PostgreSQL:
+		return fmt.Sprintf("(%s)::jsonb @> %s::jsonb", jsonArrows(col, path, false), d.GetDelimiter())
+	case dialect.SQLite:
+		if len(path) == 0 {
+			return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value = %s)", col, d.GetDelimiter())
+		}
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, %s) WHERE json_each.value = %s)", col, jsonPathLiteral(path), d.GetDelimiter())
+	default:
+		return ""
+	}
+}
+
+// GetArgument returns the bound candidate value, MySQL and PostgreSQL expect it as a JSON document.
+func (w WhereJSONContains) GetArgument(d SQLDialector) any {
+	if d.GetName() == dialect.SQLite {
+		return w.Value
+	}
+
+	encoded, err := json.Marshal(w.Value)
+	if err != nil {
+		return w.Value
+	}
+
+	return string(encoded)
+}
+
+func (w WhereJSONLength) Parse(d SQLDialector) string {
+	column, path := SplitJSONPath(w.Field)
+	col := jsonColumn(d, column)
+
+	switch d.GetName() {
+	case dialect.MySQL:
+		if len(path) == 0 {
+			return fmt.Sprintf("JSON_LENGTH(%s) %s %s", col, w.Op, d.GetDelimiter())
+		}
+		return fmt.Sprintf("JSON_LENGTH(%s, %s) %s %s", col, jsonPathLiteral(path), w.Op, d.GetDelimiter())
+	case dialect.PostgreSQL:
+		return fmt.Sprintf("jsonb_array_length((%s)::jsonb) %s %s", jsonArrows(col, path, false), w.Op, d.GetDelimiter())
+	case dialect.SQLite:
+		if len(path) == 0 {
+			return fmt.Sprintf("json_array_length(%s) %s %s", col, w.Op, d.GetDelimiter())
+		}
+		return fmt.Sprintf("json_array_length(%s, %s) %s %s", col, jsonPathLiteral(path), w.Op, d.GetDelimiter())
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,226 @@
+package clause
+
+import (
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestWhereJSONParsing(t *testing.T) {
+	where := WhereJSON{Field: "meta->address->city", Op: OperatorEqual, Value: "Denpasar"}
+
+	stmt := where.Parse(dialect.NewMySQL())
+	expected := "JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.address.city')) = ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.NewPostgres())
+	expected = `"meta"->'address'->>'city' = $1`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.New("?", "`", "`"))
+	expected = "json_extract(`meta`, '$.address.city') = ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestWhereJSONArrayIndexParsing(t *testing.T) {
+	where := WhereJSON{Field: "meta->tags->0", Op: OperatorEqual, Value: "go"}
+
+	stmt := where.Parse(dialect.New("?", "`", "`"))
+	expected := "json_extract(`meta`, '$.tags[0]') = ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.NewPostgres())
+	expected = `"meta"->'tags'->>0 = $1`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestWhereJSONContainsParsing(t *testing.T) {
+	where := WhereJSONContains{Field: "meta->tags", Value: "go"}
+
+	stmt := where.Parse(dialect.NewMySQL())
+	expected := "JSON_CONTAINS(`meta`, ?, '$.tags')"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.NewPostgres())
+	expected = `("meta"->'tags')::jsonb @> $1::jsonb`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.New("?", "`", "`"))
+	expected = "EXISTS (SELECT 1 FROM json_each(`meta`, '$.tags') WHERE json_each.value = ?)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if arg := where.GetArgument(dialect.NewMySQL()); arg != `"go"` {
+		t.Errorf("Expected argument to be JSON encoded, but got: %v", arg)
+	}
+}
+
+func TestWhereJSONLengthParsing(t *testing.T) {
+	where := WhereJSONLength{Field: "meta->tags", Op: OperatorGreaterThan, Value: 1}
+
+	stmt := where.Parse(dialect.NewMySQL())
+	expected := "JSON_LENGTH(`meta`, '$.tags') > ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.NewPostgres())
+	expected = `jsonb_array_length(("meta"->'tags')::jsonb) > $1`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.New("?", "`", "`"))
+	expected = "json_array_length(`meta`, '$.tags') > ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestSelectJSONColumn(t *testing.T) {
+	statement := Select{
+		Columns: []string{"id", "meta->name", "meta->address->city as city_name"},
+		Table:   "`users`",
+	}
+
+	stmt, _ := statement.Parse(dialect.NewMySQL())
+	expected := "SELECT `id`,JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.name')) AS `name`,JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.address.city')) AS `city_name` FROM `users`"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestUpdateJSONPath(t *testing.T) {
+	statement := Update{
+		Table: "users",
+		Rows: map[string]any{
+			"meta->address->city": "Denpasar",
+			"meta->name":          "John",
+			"age":                 25,
+		},
+	}
+
+	stmt, update := statement.Parse(dialect.New("?", "`", "`"), 1)
+	expected := "UPDATE users SET `age` = ?, `meta` = json_set(`meta`, '$.address.city', ?, '$.name', ?)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if len(update.Values) != 3 || update.Values[1] != "Denpasar" {
+		t.Errorf("Unexpected update values: %v", update.Values)
+	}
+
+	stmt, update = statement.Parse(dialect.NewPostgres(), 2)
+	expected = `UPDATE users SET "age" = $2, "meta" = jsonb_set(jsonb_set("meta"::jsonb, '{address,city}', $3::jsonb), '{name}', $4::jsonb)`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if update.Values[1] != `"Denpasar"` {
+		t.Errorf("Expected PostgreSQL JSON value to be encoded, but got: %v", update.Values[1])
+	}
+}
+
+func TestWhereJSONCastsComparedValues(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{10, `("meta"->>'age')::numeric > $1`},
+		{1.5, `("meta"->>'age')::numeric > $1`},
+		{true, `("meta"->>'age')::boolean > $1`},
+		{"10", `"meta"->>'age' > $1`},
+	}
+
+	for _, tt := range tests {
+		where := WhereJSON{Field: "meta->age", Op: OperatorGreaterThan, Value: tt.value}
+		if stmt := where.Parse(dialect.NewPostgres()); stmt != tt.expected {
+			t.Errorf("%v: expected: %s, but got: %s", tt.value, tt.expected, stmt)
+		}
+	}
+
+	where := WhereJSON{Field: "meta->age", Op: OperatorGreaterThan, Value: 10}
+	if stmt := where.Parse(dialect.NewMySQL()); stmt != "JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.age')) > ?" {
+		t.Errorf("Expected MySQL not to cast, but got: %s", stmt)
+	}
+}
+
+func TestJSONPathEscaping(t *testing.T) {
+	where := WhereJSON{Field: `meta->first name->it's "quoted" key`, Op: OperatorEqual, Value: "x"}
+
+	stmt := where.Parse(dialect.NewMySQL())
+	expected := "JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.\"first name\".\"it''s \\\"quoted\\\" key\"')) = ?"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	stmt = where.Parse(dialect.NewPostgres())
+	expected = `"meta"->'first name'->>'it''s "quoted" key' = $1`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	statement := Update{Table: "users", Rows: map[string]any{"meta->a,b": 1}}
+	stmt, _ = statement.Parse(dialect.NewPostgres(), 1)
+	expected = `UPDATE users SET "meta" = jsonb_set("meta"::jsonb, '{"a,b"}', $1::jsonb)`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
+
+func TestUpdateJSONColumnAndPath(t *testing.T) {
+	statement := Update{
+		Table: "users",
+		Rows: map[string]any{
+			"meta":       `{"name":"John"}`,
+			"meta->city": "Denpasar",
+		},
+	}
+
+	stmt, update := statement.Parse(dialect.NewMySQL(), 1)
+	expected := "UPDATE users SET `meta` = JSON_SET(?, '$.city', ?)"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if len(update.Values) != 2 || update.Values[0] != `{"name":"John"}` || update.Values[1] != "Denpasar" {
+		t.Errorf("Unexpected update values: %v", update.Values)
+	}
+
+	stmt, _ = statement.Parse(dialect.NewPostgres(), 1)
+	expected = `UPDATE users SET "meta" = jsonb_set($1::jsonb, '{city}', $2::jsonb)`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	statement.Rows = map[string]any{
+		"meta":       map[string]any{"name": "John"},
+		"meta x":     1,
+		"meta->city": "Denpasar",
+	}
+
+	stmt, update = statement.Parse(dialect.NewPostgres(), 1)
+	expected = `UPDATE users SET "meta" = jsonb_set($1::jsonb, '{city}', $2::jsonb), "meta x" = $3`
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	if len(update.Values) != 3 || update.Values[0] != `{"name":"John"}` || update.Values[1] != `"Denpasar"` || update.Values[2] != 1 {
+		t.Errorf("Unexpected update values: %v", update.Values)
+	}
+}
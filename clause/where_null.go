@@ -0,0 +1,22 @@
+package clause
+
+import (
+	"fmt"
+
+	"github.com/suryaherdiyanto/sqlbuilder/pkg"
+)
+
+type WhereNull struct {
+	Field string
+	Not   bool
+	Conj  Conjuction
+}
+
+func (w WhereNull) Parse(d SQLDialector) string {
+	field := pkg.ColumnSplitter(w.Field, d.GetColumnQuoteLeft(), d.GetColumnQuoteRight())
+	if w.Not {
+		return fmt.Sprintf("%s IS NOT NULL", field)
+	}
+
+	return fmt.Sprintf("%s IS NULL", field)
+}
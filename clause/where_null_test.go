@@ -0,0 +1,25 @@
+package clause
+
+import (
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestWhereNullParsing(t *testing.T) {
+	dialect := dialect.New("?", "`", "`")
+	where := WhereNull{Field: "deleted_at"}
+
+	stmt := where.Parse(dialect)
+	expected := "`deleted_at` IS NULL"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+
+	where2 := WhereNull{Field: "users.deleted_at", Not: true}
+	stmt = where2.Parse(dialect)
+	expected = "`users`.`deleted_at` IS NOT NULL"
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got: %s", expected, stmt)
+	}
+}
@@ -15,6 +15,12 @@ func (wi WhereIn) Parse(d SQLDialector) string {
 		return fmt.Sprintf("%s%s%s IN (%s)", d.GetColumnQuoteLeft(), wi.Field, d.GetColumnQuoteRight(), subStmt)
 
 	}
+
+	if len(wi.Values) == 0 {
+		// an empty list is not valid SQL, nothing matches
+		return "1 = 0"
+	}
+
 	inValues := ""
 
 	for i := range wi.Values {
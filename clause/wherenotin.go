@@ -15,6 +15,12 @@ func (wi WhereNotIn) Parse(d SQLDialector) string {
 		return fmt.Sprintf("%s%s%s NOT IN (%s)", d.GetColumnQuoteLeft(), wi.Field, d.GetColumnQuoteRight(), subStmt)
 
 	}
+
+	if len(wi.Values) == 0 {
+		// an empty list is not valid SQL, nothing is excluded
+		return "1 = 1"
+	}
+
 	inValues := ""
 
 	for i := range wi.Values {
@@ -48,3 +48,16 @@ func NewMySQL() *SQLDialect {
 		ColumnQuoteRight: "`",
 	}
 }
+
+// MaxParameters returns the number of bound parameters a single statement may use.
+// SQLite is limited to 999 before 3.32, PostgreSQL and MySQL to 65535.
+func (d Dialect) MaxParameters() int {
+	switch d {
+	case SQLite:
+		return 999
+	case MySQL, PostgreSQL:
+		return 65535
+	default:
+		return 999
+	}
+}
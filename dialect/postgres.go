@@ -10,6 +10,11 @@ func (p *PostgresDialect) Reset() {
 	p.placeholderIndex = 0
 }
 
+// SetPlaceholderIndex continues the placeholder sequence after $i.
+func (p *PostgresDialect) SetPlaceholderIndex(i int) {
+	p.placeholderIndex = i
+}
+
 func (p *PostgresDialect) nextPlaceholder() string {
 	p.placeholderIndex++
 	return fmt.Sprintf("$%d", p.placeholderIndex)
@@ -0,0 +1,345 @@
+// Package filter translates HTTP query parameters such as
+// ?status=active&age[gte]=18&sort=-created_at&limit=20 into SQLBuilder calls.
+// Only the fields, operators and types declared in a Schema are accepted,
+// everything else is reported as a validation error.
+package filter
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suryaherdiyanto/sqlbuilder"
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+)
+
+type Type string
+type Operator string
+
+const (
+	TypeString Type = "string"
+	TypeInt    Type = "int"
+	TypeFloat  Type = "float"
+	TypeBool   Type = "bool"
+	TypeTime   Type = "time"
+)
+
+const (
+	OperatorEqual            Operator = "eq"
+	OperatorNotEqual         Operator = "ne"
+	OperatorGreaterThan      Operator = "gt"
+	OperatorGreaterThanEqual Operator = "gte"
+	OperatorLessThan         Operator = "lt"
+	OperatorLessThanEqual    Operator = "lte"
+	OperatorLike             Operator = "like"
+	OperatorIn               Operator = "in"
+	OperatorNotIn            Operator = "nin"
+	OperatorBetween          Operator = "between"
+)
+
+const (
+	DefaultSortParam   = "sort"
+	DefaultLimitParam  = "limit"
+	DefaultOffsetParam = "offset"
+)
+
+var comparisons = map[Operator]clause.Operator{
+	OperatorEqual:            clause.OperatorEqual,
+	OperatorNotEqual:         clause.OperatorNot,
+	OperatorGreaterThan:      clause.OperatorGreaterThan,
+	OperatorGreaterThanEqual: clause.OperatorGreatherThanEqual,
+	OperatorLessThan:         clause.OperatorLessThan,
+	OperatorLessThanEqual:    clause.OperatorLessThanEqual,
+	OperatorLike:             clause.OperatorLike,
+}
+
+// Field describes a query parameter that may be filtered or sorted on.
+// Column defaults to the parameter name and Operators defaults to eq.
+type Field struct {
+	Column    string
+	Type      Type
+	Operators []Operator
+	Sortable  bool
+}
+
+// Schema is the allowlist applied to the query parameters.
+type Schema struct {
+	Fields       map[string]Field
+	DefaultLimit int64
+	MaxLimit     int64
+	SortParam    string
+	LimitParam   string
+	OffsetParam  string
+}
+
+type ValidationError struct {
+	Param   string
+	Message string
+}
+
+type ValidationErrors []ValidationError
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Param, e.Message)
+}
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Apply adds the conditions, ordering and pagination described by values to the builder.
+// Nothing is applied when any parameter is invalid, the returned error is then ValidationErrors.
+func (s Schema) Apply(b *sqlbuilder.SQLBuilder, values url.Values) (*sqlbuilder.SQLBuilder, error) {
+	var errs ValidationErrors
+	var steps []func(b *sqlbuilder.SQLBuilder)
+
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+	slices.Sort(params)
+
+	for _, param := range params {
+		switch param {
+		case s.sortParam(), s.limitParam(), s.offsetParam():
+			continue
+		}
+
+		step, err := s.parseCondition(param, values[param])
+		if err != nil {
+			errs = append(errs, *err)
+			continue
+		}
+		steps = append(steps, step)
+	}
+
+	if sort := values.Get(s.sortParam()); sort != "" {
+		for _, key := range strings.Split(sort, ",") {
+			step, err := s.parseSort(key)
+			if err != nil {
+				errs = append(errs, *err)
+				continue
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	limit, err := s.parsePagination(s.limitParam(), values.Get(s.limitParam()))
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	if limit == 0 {
+		limit = s.DefaultLimit
+	}
+	if s.MaxLimit > 0 && limit > s.MaxLimit {
+		errs = append(errs, ValidationError{Param: s.limitParam(), Message: fmt.Sprintf("must not be greater than %d", s.MaxLimit)})
+	}
+
+	offset, err := s.parsePagination(s.offsetParam(), values.Get(s.offsetParam()))
+	if err != nil {
+		errs = append(errs, *err)
+	}
+
+	if len(errs) > 0 {
+		return b, errs
+	}
+
+	for _, step := range steps {
+		step(b)
+	}
+
+	if limit > 0 {
+		b.Limit(limit)
+	}
+	if offset > 0 {
+		b.Offset(offset)
+	}
+
+	return b, nil
+}
+
+func (s Schema) parseCondition(param string, raw []string) (func(b *sqlbuilder.SQLBuilder), *ValidationError) {
+	name, op, err := splitParam(param)
+	if err != nil {
+		return nil, err
+	}
+
+	field, ok := s.Fields[name]
+	if !ok {
+		return nil, &ValidationError{Param: param, Message: "unknown filter"}
+	}
+
+	if !field.allows(op) {
+		return nil, &ValidationError{Param: param, Message: fmt.Sprintf("operator %q is not allowed", op)}
+	}
+
+	column := field.column(name)
+
+	switch op {
+	case OperatorIn, OperatorNotIn, OperatorBetween:
+		values := []any{}
+		for _, r := range raw {
+			for _, v := range strings.Split(r, ",") {
+				value, err := field.convert(param, v)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+		}
+
+		if op == OperatorBetween {
+			if len(values) != 2 {
+				return nil, &ValidationError{Param: param, Message: "between expects exactly two values"}
+			}
+			return func(b *sqlbuilder.SQLBuilder) { b.WhereBetween(column, values[0], values[1]) }, nil
+		}
+
+		if op == OperatorNotIn {
+			return func(b *sqlbuilder.SQLBuilder) { b.WhereNotIn(column, values) }, nil
+		}
+		return func(b *sqlbuilder.SQLBuilder) { b.WhereIn(column, values) }, nil
+	}
+
+	if len(raw) != 1 {
+		return nil, &ValidationError{Param: param, Message: "expects a single value"}
+	}
+
+	comparison, ok := comparisons[op]
+	if !ok {
+		return nil, &ValidationError{Param: param, Message: fmt.Sprintf("unknown operator %q", op)}
+	}
+
+	value, verr := field.convert(param, raw[0])
+	if verr != nil {
+		return nil, verr
+	}
+
+	return func(b *sqlbuilder.SQLBuilder) { b.Where(column, comparison, value) }, nil
+}
+
+func (s Schema) parseSort(key string) (func(b *sqlbuilder.SQLBuilder), *ValidationError) {
+	dir := clause.OrderDirectionASC
+	name := strings.TrimSpace(key)
+	if strings.HasPrefix(name, "-") {
+		dir = clause.OrderDirectionDESC
+		name = name[1:]
+	}
+
+	field, ok := s.Fields[name]
+	if !ok || !field.Sortable {
+		return nil, &ValidationError{Param: s.sortParam(), Message: fmt.Sprintf("cannot sort by %q", name)}
+	}
+
+	column := field.column(name)
+	return func(b *sqlbuilder.SQLBuilder) { b.OrderBy(b.QuoteColumn(column), dir) }, nil
+}
+
+func (s Schema) parsePagination(param string, raw string) (int64, *ValidationError) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || n < 0 {
+		return 0, &ValidationError{Param: param, Message: "must be a non-negative integer"}
+	}
+
+	return n, nil
+}
+
+func (s Schema) sortParam() string {
+	if s.SortParam == "" {
+		return DefaultSortParam
+	}
+
+	return s.SortParam
+}
+
+func (s Schema) limitParam() string {
+	if s.LimitParam == "" {
+		return DefaultLimitParam
+	}
+
+	return s.LimitParam
+}
+
+func (s Schema) offsetParam() string {
+	if s.OffsetParam == "" {
+		return DefaultOffsetParam
+	}
+
+	return s.OffsetParam
+}
+
+// splitParam splits "age[gte]" into the field name and its operator, eq when omitted.
+func splitParam(param string) (string, Operator, *ValidationError) {
+	name, rest, found := strings.Cut(param, "[")
+	if !found {
+		return param, OperatorEqual, nil
+	}
+
+	if !strings.HasSuffix(rest, "]") {
+		return "", "", &ValidationError{Param: param, Message: "malformed operator"}
+	}
+
+	return name, Operator(strings.TrimSuffix(rest, "]")), nil
+}
+
+func (f Field) column(name string) string {
+	if f.Column == "" {
+		return name
+	}
+
+	return f.Column
+}
+
+func (f Field) allows(op Operator) bool {
+	if len(f.Operators) == 0 {
+		return op == OperatorEqual
+	}
+
+	return slices.Contains(f.Operators, op)
+}
+
+func (f Field) convert(param string, raw string) (any, *ValidationError) {
+	raw = strings.TrimSpace(raw)
+
+	switch f.Type {
+	case TypeInt:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, &ValidationError{Param: param, Message: fmt.Sprintf("%q is not an integer", raw)}
+		}
+		return v, nil
+	case TypeFloat:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, &ValidationError{Param: param, Message: fmt.Sprintf("%q is not a number", raw)}
+		}
+		return v, nil
+	case TypeBool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, &ValidationError{Param: param, Message: fmt.Sprintf("%q is not a boolean", raw)}
+		}
+		return v, nil
+	case TypeTime:
+		for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+			if v, err := time.Parse(layout, raw); err == nil {
+				return v, nil
+			}
+		}
+		return nil, &ValidationError{Param: param, Message: fmt.Sprintf("%q is not a valid time", raw)}
+	default:
+		return raw, nil
+	}
+}
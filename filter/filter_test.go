@@ -0,0 +1,92 @@
+package filter
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+var schema = Schema{
+	Fields: map[string]Field{
+		"status":     {Type: TypeString, Operators: []Operator{OperatorEqual, OperatorIn}},
+		"age":        {Type: TypeInt, Operators: []Operator{OperatorGreaterThanEqual, OperatorLessThan, OperatorBetween}, Sortable: true},
+		"created_at": {Type: TypeTime, Sortable: true},
+		"name":       {Column: "username", Type: TypeString, Operators: []Operator{OperatorLike}},
+	},
+	MaxLimit: 100,
+}
+
+func TestApply(t *testing.T) {
+	values, _ := url.ParseQuery("status=active&age[gte]=18&name[like]=al%25&sort=-created_at,age&limit=20&offset=40")
+	builder := sqlbuilder.New(dialect.New("?", "`", "`"), nil)
+
+	_, err := schema.Apply(builder.Table("users"), values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "SELECT * FROM `users` WHERE `age` >= ? AND `username` LIKE ? AND `status` = ? ORDER BY `created_at` DESC, `age` ASC LIMIT ? OFFSET ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 5 || args[0] != int64(18) || args[1] != "al%" || args[3] != int64(20) {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestApplyInAndBetween(t *testing.T) {
+	values, _ := url.ParseQuery("status[in]=active,invited&age[between]=18,30")
+	builder := sqlbuilder.New(dialect.NewPostgres(), nil)
+
+	_, err := schema.Apply(builder.Table("users"), values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `SELECT * FROM "users" WHERE "age" BETWEEN $1 AND $2 AND "status" IN($3,$4)`
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestApplyRejectsParametersOutsideTheAllowlist(t *testing.T) {
+	values, _ := url.ParseQuery("password=secret&status[like]=a&age[gte]=old&sort=email&limit=500")
+	builder := sqlbuilder.New(dialect.New("?", "`", "`"), nil)
+
+	_, err := schema.Apply(builder.Table("users"), values)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got: %v", err)
+	}
+
+	if len(errs) != 5 {
+		t.Fatalf("Expected %d validation errors, got: %v", 5, errs)
+	}
+
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users`" {
+		t.Fatalf("Expected nothing to be applied, got: %s", sql)
+	}
+}
+
+func TestApplyRejectsUnknownOperators(t *testing.T) {
+	schema := Schema{Fields: map[string]Field{"name": {Type: TypeString, Operators: []Operator{"regex"}}}}
+	values, _ := url.ParseQuery("name[regex]=^al")
+	builder := sqlbuilder.New(dialect.New("?", "`", "`"), nil)
+
+	_, err := schema.Apply(builder.Table("users"), values)
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Param != "name[regex]" {
+		t.Fatalf("Expected a validation error for the unknown operator, got: %v", err)
+	}
+
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users`" {
+		t.Fatalf("Expected nothing to be applied, got: %s", sql)
+	}
+}
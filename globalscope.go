@@ -0,0 +1,188 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+// GlobalScopeFunc returns the column and value the rows of table must match.
+// ok is false for the tables the scope does not apply to.
+type GlobalScopeFunc func(table string) (column string, value any, ok bool)
+
+type globalScope struct {
+	name  string
+	scope GlobalScopeFunc
+}
+
+type scopedColumn struct {
+	column string
+	value  any
+}
+
+type placeholderSetter interface {
+	SetPlaceholderIndex(i int)
+}
+
+// fixedDelimiter renders a precomputed placeholder, so implicit conditions do not consume the dialect's placeholder sequence.
+type fixedDelimiter struct {
+	clause.SQLDialector
+	delimiter string
+}
+
+func (f fixedDelimiter) GetDelimiter() string {
+	return f.delimiter
+}
+
+type parsedCondition string
+
+func (c parsedCondition) Parse(_ clause.SQLDialector) string {
+	return string(c)
+}
+
+// WithGlobalScope registers a scope applied to every query of the builder: a predicate on SELECT,
+// UPDATE and DELETE, including joined tables and subqueries, and a column value on INSERT.
+func WithGlobalScope(name string, scope GlobalScopeFunc) Option {
+	return func(s *SQLBuilder) {
+		s.globalScopes = append(s.globalScopes, globalScope{name: name, scope: scope})
+	}
+}
+
+// ColumnScope constrains the given tables to the rows where column equals value.
+func ColumnScope(column string, value any, tables ...string) GlobalScopeFunc {
+	return func(table string) (string, any, bool) {
+		if !slices.Contains(tables, table) {
+			return "", nil, false
+		}
+
+		return column, value, true
+	}
+}
+
+// WithoutGlobalScope disables the named global scopes, or all of them when no name is given, for the current query.
+func (s *SQLBuilder) WithoutGlobalScope(names ...string) *SQLBuilder {
+	if len(names) == 0 {
+		s.withoutAllScopes = true
+		return s
+	}
+
+	s.withoutScopes = append(s.withoutScopes, names...)
+	return s
+}
+
+func (s *SQLBuilder) scopedColumns(table string) []scopedColumn {
+	if s.withoutAllScopes || table == "" {
+		return nil
+	}
+
+	columns := []scopedColumn{}
+	for _, g := range s.globalScopes {
+		if slices.Contains(s.withoutScopes, g.name) {
+			continue
+		}
+
+		if column, value, ok := g.scope(table); ok {
+			columns = append(columns, scopedColumn{column: column, value: value})
+		}
+	}
+
+	return columns
+}
+
+// globalScopeClauses returns the joins with their scope conditions, the scope conditions of the where clause
+// and the values bound by both. PostgreSQL placeholders are numbered after the builder's own values.
+func (s *SQLBuilder) globalScopeClauses() ([]clause.JoinParser, []string, []any) {
+	joins := s.joins
+	where := []string{}
+	values := []any{}
+
+	if len(s.globalScopes) == 0 {
+		return joins, where, values
+	}
+
+	condition := func(table string, sc scopedColumn) string {
+		delimiter := s.Dialect.GetDelimiter()
+		if s.Dialect.GetName() == dialect.PostgreSQL {
+			delimiter = fmt.Sprintf("$%d", s.placeholderOffset+len(s.Values)+len(values)+1)
+		}
+		values = append(values, sc.value)
+
+		w := clause.Where{Field: table + "." + sc.column, Op: clause.OperatorEqual}
+		return w.Parse(fixedDelimiter{SQLDialector: s.Dialect, delimiter: delimiter})
+	}
+
+	crossJoined := []string{}
+
+	// UPDATE and DELETE statements render their own joins, the scope conditions of the joined tables go to the where clause
+	if s.statement == mutationKind {
+		joins = nil
+		for _, j := range s.joins {
+			switch join := j.(type) {
+			case clause.Join:
+				crossJoined = append(crossJoined, join.SecondTable)
+			case clause.CrossJoin:
+				crossJoined = append(crossJoined, join.SecondTable)
+			}
+		}
+	} else {
+		joins = make([]clause.JoinParser, 0, len(s.joins))
+		for _, j := range s.joins {
+			switch join := j.(type) {
+			case clause.Join:
+				join.Conditions = slices.Clone(join.Conditions)
+				for _, sc := range s.scopedColumns(join.SecondTable) {
+					join.Conditions = append(join.Conditions, parsedCondition(condition(join.SecondTable, sc)))
+				}
+				joins = append(joins, join)
+			case clause.CrossJoin:
+				crossJoined = append(crossJoined, join.SecondTable)
+				joins = append(joins, join)
+			default:
+				joins = append(joins, j)
+			}
+		}
+	}
+
+	for _, table := range append([]string{s.table}, crossJoined...) {
+		for _, sc := range s.scopedColumns(table) {
+			where = append(where, condition(table, sc))
+		}
+	}
+
+	return joins, where, values
+}
+
+// scopeRows sets the global scope columns on copies of the inserted rows.
+func (s *SQLBuilder) scopeRows(rows []map[string]any) []map[string]any {
+	columns := s.scopedColumns(s.table)
+	if len(columns) == 0 {
+		return rows
+	}
+
+	scoped := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		r := make(map[string]any, len(row)+len(columns))
+		for k, v := range row {
+			r[k] = v
+		}
+		for _, sc := range columns {
+			r[sc.column] = sc.value
+		}
+		scoped = append(scoped, r)
+	}
+
+	return scoped
+}
+
+// syncPlaceholders moves the dialect's placeholder sequence past the values bound so far,
+// which includes the implicit values of consumed subqueries.
+func (s *SQLBuilder) syncPlaceholders() {
+	setter, ok := s.Dialect.(placeholderSetter)
+	if !ok {
+		return
+	}
+
+	setter.SetPlaceholderIndex(s.placeholderOffset + len(s.Values))
+}
@@ -0,0 +1,156 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestGlobalScopeSelect(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "orders", "customers")))
+
+	builder.Table("orders").
+		SelectRelevance([]string{"note"}, "gift", "score").
+		LeftJoin("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("status", clause.OperatorEqual, "paid").
+		OrWhere("status", clause.OperatorEqual, "shipped").
+		Limit(5)
+
+	expected := "SELECT *,bm25(`orders`) AS `score` FROM `orders` LEFT JOIN `customers` ON `customers`.`id` = `orders`.`customer_id` AND `customers`.`tenant_id` = ? WHERE `orders`.`tenant_id` = ? AND (`status` = ? OR `status` = ?) LIMIT ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 5 || args[0] != 7 || args[1] != 7 || args[2] != "paid" || args[4] != int64(5) {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+
+	builder.Table("orders").WithoutGlobalScope("tenant").Where("id", clause.OperatorEqual, 1)
+	if sql := builder.GetSql(); sql != "SELECT * FROM `orders` WHERE `id` = ?" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder.Table("users")
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users`" {
+		t.Fatalf("Expected unregistered tables to be untouched, got: %s", sql)
+	}
+}
+
+func TestGlobalScopeSubqueryPG(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "orders", "customers")))
+
+	builder.Table("orders").
+		Where("status", clause.OperatorEqual, "paid").
+		WhereFunc("customer_id", "IN", func(b Builder) *SQLBuilder {
+			return b.Table("customers").Select("id").Where("vip", clause.OperatorEqual, true)
+		}).
+		Where("total", clause.OperatorGreaterThan, 100)
+
+	expected := `SELECT * FROM "orders" WHERE "orders"."tenant_id" = $5 AND "status" = $1 AND "customer_id" IN (SELECT "id" FROM "customers" WHERE "customers"."tenant_id" = $3 AND "vip" = $2) AND "total" > $4`
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 5 || args[1] != true || args[2] != 7 || args[3] != 100 || args[4] != 7 {
+		t.Fatalf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestGlobalScopeInsert(t *testing.T) {
+	tests := []struct {
+		dialect  clause.SQLDialector
+		expected string
+	}{
+		{dialect.New("?", "`", "`"), "INSERT INTO `users`(`name`,`tenant_id`) VALUES(?,?)"},
+		{dialect.NewPostgres(), `INSERT INTO "users"("name","tenant_id") VALUES($1,$2)`},
+	}
+
+	for _, tt := range tests {
+		builder := New(tt.dialect, db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "users")))
+		if err := builder.Table("users").prepareInsert([]map[string]any{{"name": "x"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		if sql := builder.GetSql(); sql != tt.expected {
+			t.Errorf("Unexpected SQL result, got: %s", sql)
+		}
+
+		if args := builder.GetArguments(); len(args) != 2 || args[0] != "x" || args[1] != 7 {
+			t.Errorf("Expected the insert values only, got: %v", args)
+		}
+	}
+}
+
+func TestExecuteGlobalScope(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dba.Exec(`
+		CREATE TABLE orders(
+			id integer primary key,
+			tenant_id integer,
+			total integer
+		);
+		INSERT INTO orders values(1, 1, 100);
+		INSERT INTO orders values(2, 2, 200);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba, WithGlobalScope("tenant", ColumnScope("tenant_id", 1, "orders")))
+
+	id, err := builder.Table("orders").Insert(map[string]any{"total": 300, "tenant_id": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inserted struct {
+		TenantId int64 `db:"tenant_id"`
+		Total    int64 `db:"total"`
+	}
+	if err = builder.Table("orders").Where("id", clause.OperatorEqual, id).Get(&inserted); err != nil {
+		t.Fatal(err)
+	}
+	if inserted.TenantId != 1 || inserted.Total != 300 {
+		t.Errorf("Expected the order to be inserted for tenant 1 with a total of 300, got: %+v", inserted)
+	}
+
+	res, err := builder.Table("orders").Where("total", clause.OperatorGreaterThan, 0).Update(map[string]any{"total": 1})
+	if err != nil {
+		t.Fatalf("update failed: %v, sql: %s", err, builder.GetSql())
+	}
+	if affected, _ := res.RowsAffected(); affected != 2 {
+		t.Errorf("Expected %d updated rows, but got: %d", 2, affected)
+	}
+
+	count, err := builder.Table("orders").Where("total", clause.OperatorEqual, 1).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("Expected count to be %d, but got: %d", 2, count)
+	}
+
+	res, err = builder.Table("orders").Where("total", clause.OperatorGreaterThan, 0).Delete()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected, _ := res.RowsAffected(); affected != 2 {
+		t.Errorf("Expected %d deleted rows, but got: %d", 2, affected)
+	}
+
+	count, err = builder.Table("orders").WithoutGlobalScope().Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("Expected the other tenant's order to remain, but got count: %d", count)
+	}
+}
@@ -0,0 +1,28 @@
+package sqlbuilder
+
+import "errors"
+
+var ErrMissingWhere = errors.New("sqlbuilder: refusing to write every row of the table without a where clause, call AllowFullTable to confirm")
+
+// WithFullTableWrites lets Update, Delete and Truncate run without a where clause on every query of the builder.
+func WithFullTableWrites(enabled bool) Option {
+	return func(s *SQLBuilder) {
+		s.fullTableWrites = enabled
+	}
+}
+
+// AllowFullTable confirms that the next Update, Delete or Truncate may write every row of the table.
+func (s *SQLBuilder) AllowFullTable() *SQLBuilder {
+	s.allowFullTable = true
+	return s
+}
+
+// guardFullTable returns ErrMissingWhere when the statement has no condition of its own and was not allowed
+// to write the full table. Global scopes and soft delete conditions do not count as conditions.
+func (s *SQLBuilder) guardFullTable() error {
+	if s.whereClauseStatement != "" || s.allowFullTable || s.fullTableWrites {
+		return nil
+	}
+
+	return ErrMissingWhere
+}
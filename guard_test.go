@@ -0,0 +1,91 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestGuardFullTable(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedPosts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba, WithSoftDeletes("posts"), WithGlobalScope("published", ColumnScope("views", 10, "posts")))
+
+	if _, err = builder.Table("posts").Update(map[string]any{"title": "all"}); !errors.Is(err, ErrMissingWhere) {
+		t.Errorf("Expected ErrMissingWhere on Update, got: %v", err)
+	}
+
+	if _, err = builder.Table("posts").Delete(); !errors.Is(err, ErrMissingWhere) {
+		t.Errorf("Expected ErrMissingWhere on Delete, got: %v", err)
+	}
+
+	if _, err = builder.Table("posts").ForceDelete(); !errors.Is(err, ErrMissingWhere) {
+		t.Errorf("Expected ErrMissingWhere on ForceDelete, got: %v", err)
+	}
+
+	if _, err = builder.Table("posts").Increment("views", 1, nil); !errors.Is(err, ErrMissingWhere) {
+		t.Errorf("Expected ErrMissingWhere on Increment, got: %v", err)
+	}
+
+	if _, err = builder.Table("posts").WhereMap(map[string]any{}).Delete(); !errors.Is(err, ErrMissingWhere) {
+		t.Errorf("Expected ErrMissingWhere with empty conditions, got: %v", err)
+	}
+
+	count, err := builder.Table("posts").WithTrashed().WithoutGlobalScope().Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 3 {
+		t.Fatalf("Expected the refused statements to leave the 3 posts, got: %d", count)
+	}
+
+	res, err := builder.Table("posts").AllowFullTable().Update(map[string]any{"title": "all"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		t.Errorf("Expected the scoped post to be updated, got: %d", affected)
+	}
+
+	if _, err = builder.Table("posts").Where("id", clause.OperatorEqual, 1).Delete(); err != nil {
+		t.Errorf("Expected a filtered delete to run, got: %v", err)
+	}
+
+	if _, err = builder.Table("posts").Update(map[string]any{"title": "all"}); !errors.Is(err, ErrMissingWhere) {
+		t.Errorf("Expected AllowFullTable to apply to a single statement, got: %v", err)
+	}
+}
+
+func TestWithFullTableWrites(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedPosts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba, WithFullTableWrites(true))
+
+	res, err := builder.Table("posts").Delete()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected != 3 {
+		t.Errorf("Expected every post to be deleted, got: %d", affected)
+	}
+}
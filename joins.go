@@ -0,0 +1,164 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+var ErrUnsupportedJoin = errors.New("sqlbuilder: the first join of an UPDATE or DELETE must be an inner or cross join")
+var ErrJoinedMutationTail = errors.New("sqlbuilder: MySQL cannot order or limit an UPDATE or DELETE with joins")
+var ErrScopedOuterJoin = errors.New("sqlbuilder: an UPDATE or DELETE cannot outer join a table restricted by a global scope")
+
+// checkJoinedTail rejects the ORDER BY and LIMIT of a MySQL UPDATE or DELETE with joins, which MySQL refuses
+// for multi-table statements and which cannot go through keySubquery, as MySQL neither limits an IN subquery
+// nor selects from the table being written.
+func (s *SQLBuilder) checkJoinedTail() error {
+	if s.Dialect.GetName() == dialect.MySQL && len(s.joins) > 0 && s.tailClauseStatement != "" {
+		return ErrJoinedMutationTail
+	}
+
+	return nil
+}
+
+// checkScopedJoins rejects the outer joins of an UPDATE or DELETE to scoped tables: their scope conditions
+// are part of the where clause, which would turn the outer join into an inner one.
+func (s *SQLBuilder) checkScopedJoins() error {
+	for _, j := range s.joins {
+		join, ok := j.(clause.Join)
+		if !ok || join.Type == clause.InnerJoin || len(s.scopedColumns(join.SecondTable)) == 0 {
+			continue
+		}
+
+		return fmt.Errorf("%w, got: %s %s", ErrScopedOuterJoin, join.Type, join.SecondTable)
+	}
+
+	return nil
+}
+
+// mutationJoins renders the joins of an UPDATE or DELETE as written, their scope conditions are part of the where clause.
+func (s *SQLBuilder) mutationJoins() string {
+	stmts := make([]string, 0, len(s.joins))
+	for _, join := range s.joins {
+		stmts = append(stmts, join.Parse(s.Dialect))
+	}
+
+	return strings.Join(stmts, " ")
+}
+
+// mutationFrom renders the joined tables listed in the FROM of a PostgreSQL or SQLite UPDATE, or the USING
+// of a PostgreSQL DELETE, and the conditions of the inner joins, which move to the where clause.
+func (s *SQLBuilder) mutationFrom() (string, string, error) {
+	from := ""
+	conditions := []string{}
+
+	for i, j := range s.joins {
+		switch join := j.(type) {
+		case clause.Join:
+			if join.Type != clause.InnerJoin {
+				if i == 0 {
+					return "", "", fmt.Errorf("%w, got: %s", ErrUnsupportedJoin, join.Type)
+				}
+				from += " " + join.Parse(s.Dialect)
+				continue
+			}
+
+			from += ", " + s.quoteTable(join.SecondTable)
+			conditions = append(conditions, join.ParseOn(s.Dialect))
+		case clause.CrossJoin:
+			from += ", " + s.quoteTable(join.SecondTable)
+		default:
+			return "", "", ErrUnsupportedJoin
+		}
+	}
+
+	return strings.TrimPrefix(from, ", "), strings.Join(conditions, " "+string(clause.ConjuctionAnd)+" "), nil
+}
+
+// emulatesMutationTail reports whether an UPDATE or DELETE selects its rows through keySubquery: PostgreSQL
+// has no ORDER BY and LIMIT on them, and neither have the joined statements of SQLite. The joined statements
+// of MySQL are rejected by checkJoinedTail.
+func (s *SQLBuilder) emulatesMutationTail() bool {
+	if s.tailClauseStatement == "" {
+		return false
+	}
+
+	switch s.Dialect.GetName() {
+	case dialect.PostgreSQL:
+		return true
+	case dialect.MySQL:
+		return false
+	default:
+		return len(s.joins) > 0
+	}
+}
+
+// keySubquery renders the condition matching the primary keys of the rows selected by the joins,
+// where clause and tail clauses of the builder, ctid on PostgreSQL and rowid on SQLite without primary key.
+func (s *SQLBuilder) keySubquery() string {
+	key := s.QuoteColumn(s.table + "." + s.primaryKey)
+	if s.primaryKey == "" {
+		key = s.quoteTable(s.table) + ".rowid"
+		if s.Dialect.GetName() == dialect.PostgreSQL {
+			key = s.quoteTable(s.table) + ".ctid"
+		}
+	}
+
+	subquery := joinStatement("SELECT "+key+" FROM "+s.tempTable, s.mutationJoins(), s.whereClause(), s.tailClauseStatement)
+
+	return fmt.Sprintf("%s IN (%s)", key, subquery)
+}
+
+// joinStatement joins the non-empty parts of a statement with spaces.
+func joinStatement(parts ...string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(part string) bool { return part == "" }), " ")
+}
+
+// prepareJoinedDelete renders a DELETE restricted by joins: a multi-table DELETE on MySQL, DELETE ... USING
+// on PostgreSQL and, as SQLite has neither, a DELETE of the primary keys selected through the joins.
+func (s *SQLBuilder) prepareJoinedDelete() error {
+	switch s.Dialect.GetName() {
+	case dialect.MySQL:
+		s.rawStatement = joinStatement("DELETE "+s.tempTable+" FROM "+s.tempTable, s.mutationJoins(), s.whereClause(), s.tailClauseStatement)
+	case dialect.PostgreSQL:
+		using, conditions, err := s.mutationFrom()
+		if err != nil {
+			return err
+		}
+
+		where := s.whereClause()
+		if conditions != "" {
+			where = prependImplicitCondition(where, conditions)
+		}
+		s.rawStatement = joinStatement("DELETE FROM "+s.tempTable+" USING "+using, where)
+	default:
+		s.rawStatement = "DELETE FROM " + s.tempTable + " WHERE " + s.keySubquery()
+	}
+
+	s.rawStatement += s.returningClause()
+
+	return nil
+}
+
+// unqualifiedColumns drops the target table from the updated columns, only MySQL accepts them qualified.
+func (s *SQLBuilder) unqualifiedColumns(data map[string]any) map[string]any {
+	if s.Dialect.GetName() == dialect.MySQL {
+		return data
+	}
+
+	prefix := s.table + "."
+	columns := make(map[string]any, len(data))
+	for column, value := range data {
+		columns[strings.TrimPrefix(column, prefix)] = value
+	}
+
+	return columns
+}
+
+func (s *SQLBuilder) quoteTable(table string) string {
+	return s.Dialect.GetColumnQuoteLeft() + table + s.Dialect.GetColumnQuoteRight()
+}
@@ -0,0 +1,382 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func seedOrders(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE customers(
+			id integer primary key,
+			name TEXT,
+			blocked integer default 0
+		);
+		CREATE TABLE orders(
+			id integer primary key,
+			customer_id integer,
+			status TEXT
+		);
+		INSERT INTO customers values(1, 'alice', 0);
+		INSERT INTO customers values(2, 'bob', 1);
+		INSERT INTO orders values(1, 1, 'pending');
+		INSERT INTO orders values(2, 2, 'pending');
+		INSERT INTO orders values(3, 2, 'paid');
+	`)
+
+	return err
+}
+
+func TestUpdateWithJoins(t *testing.T) {
+	tests := []struct {
+		dialect  clause.SQLDialector
+		expected string
+	}{
+		{dialect.NewMySQL(), "UPDATE `orders` INNER JOIN `customers` ON `customers`.`id` = `orders`.`customer_id` SET `orders`.`status` = ? WHERE `customers`.`blocked` = ?"},
+		{dialect.NewPostgres(), `UPDATE "orders" SET "status" = $2 FROM "customers" WHERE "customers"."id" = "orders"."customer_id" AND "customers"."blocked" = $1`},
+		{dialect.New("?", "`", "`"), "UPDATE `orders` SET `status` = ? FROM `customers` WHERE `customers`.`id` = `orders`.`customer_id` AND `customers`.`blocked` = ?"},
+	}
+
+	for _, tt := range tests {
+		builder := New(tt.dialect, db)
+		builder.Table("orders").
+			Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+			Where("customers.blocked", clause.OperatorEqual, 1)
+
+		if err := builder.prepareUpdate(map[string]any{"orders.status": "cancelled"}); err != nil {
+			t.Fatal(err)
+		}
+
+		if sql := builder.GetSql(); sql != tt.expected {
+			t.Errorf("%s: unexpected SQL result, got: %s", tt.dialect.GetName(), sql)
+		}
+
+		args := builder.GetArguments()
+		if tt.dialect.GetName() == dialect.PostgreSQL {
+			args[0], args[1] = args[1], args[0]
+		}
+		if len(args) != 2 || args[0] != "cancelled" || args[1] != 1 {
+			t.Errorf("%s: unexpected arguments, got: %v", tt.dialect.GetName(), args)
+		}
+	}
+}
+
+func TestDeleteWithJoins(t *testing.T) {
+	tests := []struct {
+		dialect  clause.SQLDialector
+		expected string
+	}{
+		{dialect.NewMySQL(), "DELETE `orders` FROM `orders` INNER JOIN `customers` ON `customers`.`id` = `orders`.`customer_id` WHERE `customers`.`blocked` = ? OR `orders`.`status` = ?"},
+		{dialect.NewPostgres(), `DELETE FROM "orders" USING "customers" WHERE "customers"."id" = "orders"."customer_id" AND ("customers"."blocked" = $1 OR "orders"."status" = $2)`},
+		{dialect.New("?", "`", "`"), "DELETE FROM `orders` WHERE `orders`.`id` IN (SELECT `orders`.`id` FROM `orders` INNER JOIN `customers` ON `customers`.`id` = `orders`.`customer_id` WHERE `customers`.`blocked` = ? OR `orders`.`status` = ?)"},
+	}
+
+	for _, tt := range tests {
+		builder := New(tt.dialect, db)
+		builder.Table("orders").
+			Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+			Where("customers.blocked", clause.OperatorEqual, 1).
+			OrWhere("orders.status", clause.OperatorEqual, "failed")
+
+		if err := builder.prepareDelete(); err != nil {
+			t.Fatal(err)
+		}
+
+		if sql := builder.GetSql(); sql != tt.expected {
+			t.Errorf("%s: unexpected SQL result, got: %s", tt.dialect.GetName(), sql)
+		}
+	}
+}
+
+func TestMutationJoinsWithGlobalScope(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "orders", "customers")))
+
+	builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1)
+
+	if err := builder.prepareUpdate(map[string]any{"status": "cancelled"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "orders" SET "status" = $2 FROM "customers" WHERE "customers"."id" = "orders"."customer_id" AND "orders"."tenant_id" = $3 AND "customers"."tenant_id" = $4 AND "customers"."blocked" = $1`
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 4 || args[0] != 1 || args[1] != "cancelled" || args[2] != 7 || args[3] != 7 {
+		t.Errorf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestMutationOuterJoinsWithGlobalScope(t *testing.T) {
+	builder := New(dialect.NewMySQL(), db, WithGlobalScope("tenant", ColumnScope("tenant_id", 7, "orders", "customers")))
+
+	err := builder.Table("orders").
+		LeftJoin("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.id", clause.OperatorEqual, nil).
+		prepareForceDelete()
+	if !errors.Is(err, ErrScopedOuterJoin) {
+		t.Fatalf("Expected ErrScopedOuterJoin, got: %v", err)
+	}
+
+	builder.Table("orders").
+		LeftJoin("notes", "notes.order_id", clause.OperatorEqual, "orders.id").
+		WhereNull("notes.id")
+
+	if err = builder.prepareUpdate(map[string]any{"status": "unnoted"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "UPDATE `orders` LEFT JOIN `notes` ON `notes`.`order_id` = `orders`.`id` SET `status` = ? WHERE `orders`.`tenant_id` = ? AND `notes`.`id` IS NULL"
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestMutationWithLeadingOuterJoin(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db)
+
+	err := builder.Table("orders").
+		LeftJoin("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.id", clause.OperatorEqual, nil).
+		prepareForceDelete()
+	if !errors.Is(err, ErrUnsupportedJoin) {
+		t.Fatalf("Expected ErrUnsupportedJoin, got: %v", err)
+	}
+}
+
+func TestExecuteMutationsWithJoins(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedOrders(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	res, err := builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1).
+		Where("orders.status", clause.OperatorEqual, "pending").
+		Update(map[string]any{"status": "cancelled"})
+	if err != nil {
+		t.Fatalf("update failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 cancelled order, got: %d", affected)
+	}
+
+	res, err = builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1).
+		Delete()
+	if err != nil {
+		t.Fatalf("delete failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if affected, _ := res.RowsAffected(); affected != 2 {
+		t.Errorf("Expected 2 deleted orders, got: %d", affected)
+	}
+
+	var statuses []map[string]any
+	if err = builder.Table("orders").Select("status").Get(&statuses); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(statuses) != 1 || statuses[0]["status"] != "pending" {
+		t.Errorf("Expected the pending order of alice to remain, got: %v", statuses)
+	}
+}
+
+func TestMutationOrderAndLimit(t *testing.T) {
+	builder := New(dialect.NewMySQL(), db)
+	builder.Table("logs").Where("level", clause.OperatorEqual, "debug").OrderBy("id", clause.OrderDirectionASC).Limit(1000)
+	if err := builder.prepareDelete(); err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "DELETE FROM `logs` WHERE `level` = ? ORDER BY id ASC LIMIT ?" {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder = New(dialect.New("?", "`", "`"), db)
+	builder.Table("logs").Where("level", clause.OperatorEqual, "debug").OrderBy("id", clause.OrderDirectionASC).Limit(1000)
+	if err := builder.prepareUpdate(map[string]any{"archived": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "UPDATE `logs` SET `archived` = ? WHERE `level` = ? ORDER BY id ASC LIMIT ?" {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 3 || args[0] != true || args[1] != "debug" || args[2] != int64(1000) {
+		t.Errorf("Unexpected arguments, got: %v", args)
+	}
+}
+
+func TestJoinedMutationOrderAndLimitMySQL(t *testing.T) {
+	builder := New(dialect.NewMySQL(), db)
+
+	err := builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1).
+		OrderBy("orders.id", clause.OrderDirectionASC).
+		Limit(10).
+		prepareUpdate(map[string]any{"orders.status": "cancelled"})
+	if !errors.Is(err, ErrJoinedMutationTail) {
+		t.Fatalf("Expected ErrJoinedMutationTail, got: %v", err)
+	}
+
+	err = builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1).
+		Limit(10).
+		prepareForceDelete()
+	if !errors.Is(err, ErrJoinedMutationTail) {
+		t.Fatalf("Expected ErrJoinedMutationTail, got: %v", err)
+	}
+
+	builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1)
+	if err = builder.prepareForceDelete(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "DELETE `orders` FROM `orders` INNER JOIN `customers` ON `customers`.`id` = `orders`.`customer_id` WHERE `customers`.`blocked` = ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestMutationOrderAndLimitPG(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db, WithSoftDeletes("logs"))
+	builder.Table("logs").Where("level", clause.OperatorEqual, "debug").OrderBy("id", clause.OrderDirectionASC).Limit(1000)
+	if err := builder.prepareUpdate(map[string]any{"archived": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "logs" SET "archived" = $3 WHERE "logs"."id" IN (SELECT "logs"."id" FROM "logs" WHERE "level" = $1 AND "logs"."deleted_at" IS NULL ORDER BY id ASC LIMIT $2)`
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+
+	args := builder.GetArguments()
+	if len(args) != 3 || args[0] != "debug" || args[1] != int64(1000) || args[2] != true {
+		t.Errorf("Unexpected arguments, got: %v", args)
+	}
+
+	builder = New(dialect.NewPostgres(), db, WithPrimaryKey(""))
+	builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, true).
+		Limit(100)
+	if err := builder.prepareForceDelete(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `DELETE FROM "orders" WHERE "orders".ctid IN (SELECT "orders".ctid FROM "orders" INNER JOIN "customers" ON "customers"."id" = "orders"."customer_id" WHERE "customers"."blocked" = $1 LIMIT $2)`
+	if sql := builder.GetSql(); sql != expected {
+		t.Errorf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestExecuteJoinedMutationWithLimit(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedOrders(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	res, err := builder.Table("orders").
+		Join("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		Where("customers.blocked", clause.OperatorEqual, 1).
+		OrderBy("orders.id", clause.OrderDirectionDESC).
+		Limit(1).
+		Update(map[string]any{"status": "cancelled"})
+	if err != nil {
+		t.Fatalf("update failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 updated order, got: %d", affected)
+	}
+
+	var status string
+	if err = builder.Table("orders").Select("status").Where("id", clause.OperatorEqual, 3).Get(&status); err != nil {
+		t.Fatal(err)
+	}
+
+	if status != "cancelled" {
+		t.Errorf("Expected the latest order of bob to be cancelled, got: %s", status)
+	}
+}
+
+func TestExecuteScanNestedStructs(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedOrders(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = dba.Exec("INSERT INTO orders values(4, 9, 'orphaned')"); err != nil {
+		t.Fatal(err)
+	}
+
+	type Order struct {
+		Id         int64  `db:"id"`
+		CustomerId int64  `db:"customer_id"`
+		Status     string `db:"status"`
+	}
+	type Customer struct {
+		Id   int64  `db:"id"`
+		Name string `db:"name"`
+	}
+	type Row struct {
+		Order
+		Customer *Customer
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	var rows []Row
+	err = builder.Table("orders").
+		Select("orders.*", "customers.id AS customer__id", "customers.name AS `customer.name`").
+		LeftJoin("customers", "customers.id", clause.OperatorEqual, "orders.customer_id").
+		OrderBy("orders.id", clause.OrderDirectionASC).
+		Get(&rows)
+	if err != nil {
+		t.Fatalf("select failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if len(rows) != 4 || len(builder.UnmappedColumns()) != 0 {
+		t.Fatalf("Expected 4 fully mapped rows, got: %+v, unmapped: %v", rows, builder.UnmappedColumns())
+	}
+
+	if rows[0].Id != 1 || rows[0].Status != "pending" || rows[0].Customer == nil || rows[0].Customer.Id != 1 || rows[0].Customer.Name != "alice" {
+		t.Errorf("Unexpected first row: %+v", rows[0])
+	}
+
+	if rows[3].Id != 4 || rows[3].Customer != nil {
+		t.Errorf("Expected the orphaned order to have no customer, got: %+v", rows[3])
+	}
+}
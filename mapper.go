@@ -0,0 +1,267 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// fieldMap describes a struct field mapped to a column through its db tag,
+// e.g. `db:"id,pk"`, `db:"created_at,readonly"` or `db:"nickname,omitempty"`.
+// Untagged fields are inferred from the snake_case field name and mapped like tagged ones without options,
+// the fields of nested structs are only read by the scanners.
+type fieldMap struct {
+	column    string
+	index     []int
+	omitEmpty bool
+	readOnly  bool
+	pk        bool
+	inferred  bool
+	nested    bool
+}
+
+// mapMode selects the fields written by columnValues.
+type mapMode int
+
+const (
+	// mapInsert skips readonly fields, and omitempty and pk fields holding their zero value.
+	mapInsert mapMode = iota
+	// mapUpdate skips readonly and pk fields, and omitempty fields holding their zero value.
+	mapUpdate
+	// mapConditions skips every field holding its zero value.
+	mapConditions
+)
+
+// fieldCache holds the []fieldMap of each struct type.
+var fieldCache sync.Map
+
+var (
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// structFieldMaps lists the mapped fields of a struct type, walking into embedded structs that have no
+// column name of their own, and into nested struct fields, whose columns are prefixed with the field's
+// column, e.g. company.name. Fields tagged `db:"-"` and unexported fields are skipped.
+// The result is cached per type and must not be modified.
+func structFieldMaps(t reflect.Type) []fieldMap {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]fieldMap)
+	}
+
+	fields, _ := fieldCache.LoadOrStore(t, dominantFields(collectFields(t, map[reflect.Type]bool{t: true})))
+	return fields.([]fieldMap)
+}
+
+// collectFields lists the fields of t, visiting tracks the structs being walked so recursive types end.
+func collectFields(t reflect.Type, visiting map[reflect.Type]bool) []fieldMap {
+	fields := []fieldMap{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		st := f.Type
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if isNestedStruct(st) && visiting[st] {
+			continue
+		}
+		walk := isNestedStruct(st)
+
+		if f.Anonymous && name == "" && walk {
+			// like encoding/json, an unexported embedded pointer cannot be allocated and is ignored
+			if !f.IsExported() && f.Type.Kind() == reflect.Ptr {
+				continue
+			}
+
+			visiting[st] = true
+			for _, field := range collectFields(st, visiting) {
+				field.index = append([]int{i}, field.index...)
+				fields = append(fields, field)
+			}
+			delete(visiting, st)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if walk {
+			prefix := name
+			if prefix == "" {
+				prefix = snakeCase(f.Name)
+			}
+
+			visiting[st] = true
+			for _, field := range collectFields(st, visiting) {
+				field.column = prefix + "." + field.column
+				field.index = append([]int{i}, field.index...)
+				field.nested = true
+				fields = append(fields, field)
+			}
+			delete(visiting, st)
+			continue
+		}
+
+		if name == "" {
+			fields = append(fields, fieldMap{column: snakeCase(f.Name), index: []int{i}, inferred: true})
+			continue
+		}
+
+		field := fieldMap{column: name, index: []int{i}}
+		for _, option := range strings.Split(options, ",") {
+			switch strings.TrimSpace(option) {
+			case "omitempty":
+				field.omitEmpty = true
+			case "readonly":
+				field.readOnly = true
+			case "pk":
+				field.pk = true
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// isNestedStruct reports whether fields of type t are walked into rather than mapped to a single column:
+// structs other than time.Time, sql.Scanner and driver.Valuer implementations.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+
+	ptr := reflect.PointerTo(t)
+	return !ptr.Implements(valuerType) && !ptr.Implements(scannerType)
+}
+
+// dominantFields keeps a single field per column: a tagged field over an inferred one,
+// then the least deeply embedded field, as Go does for promoted fields.
+func dominantFields(fields []fieldMap) []fieldMap {
+	best := map[string]fieldMap{}
+	for _, field := range fields {
+		if current, ok := best[field.column]; !ok || field.outranks(current) {
+			best[field.column] = field
+		}
+	}
+
+	kept := make([]fieldMap, 0, len(best))
+	for _, field := range fields {
+		if current, ok := best[field.column]; ok && slices.Equal(current.index, field.index) {
+			kept = append(kept, field)
+			delete(best, field.column)
+		}
+	}
+
+	return kept
+}
+
+func (f fieldMap) outranks(other fieldMap) bool {
+	if f.inferred != other.inferred {
+		return !f.inferred
+	}
+
+	return len(f.index) < len(other.index)
+}
+
+// snakeCase converts a Go field name to its column name, e.g. CreatedAt to created_at and UserID to user_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// columnValues maps the columns of a struct value to the values of its fields, as selected by mode.
+// Values are passed to the driver as they are, so driver.Valuer implementations are honoured.
+// The fields of nested structs are never written.
+func columnValues(v reflect.Value, mode mapMode) map[string]any {
+	values := map[string]any{}
+
+	for _, field := range structFieldMaps(v.Type()) {
+		if field.nested {
+			continue
+		}
+
+		fv, ok := fieldByIndex(v, field.index, false)
+		if !ok {
+			continue
+		}
+
+		zero := fv.IsZero()
+		switch {
+		case mode != mapConditions && field.readOnly:
+			continue
+		case mode == mapUpdate && field.pk:
+			continue
+		case zero && (mode == mapConditions || field.omitEmpty || (mode == mapInsert && field.pk)):
+			continue
+		}
+
+		values[field.column] = fv.Interface()
+	}
+
+	return values
+}
+
+// fieldByIndex returns the field at index, allocating the nil embedded pointers on the way when alloc is set.
+// It reports false when a nil embedded pointer is not allocated.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+
+	return v, true
+}
+
+// structValue dereferences data down to a struct value.
+func structValue(data any) (reflect.Value, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("sqlbuilder: expected a struct, passed a nil %T", data)
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("sqlbuilder: expected a struct, passed: %T", data)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,443 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+type upperName string
+
+func (u upperName) Value() (driver.Value, error) {
+	return strings.ToUpper(string(u)), nil
+}
+
+type Contact struct {
+	Email string `db:"email"`
+}
+
+type Member struct {
+	Id int64 `db:"id,pk"`
+	Contact
+	Username  upperName `db:"username"`
+	Age       int       `db:"age,omitempty"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+	Password  string    `db:"-"`
+	note      string
+}
+
+func TestColumnValues(t *testing.T) {
+	member := Member{Contact: Contact{Email: "alice@example.com"}, Username: "alice"}
+	v := reflect.ValueOf(member)
+
+	insert := columnValues(v, mapInsert)
+	if len(insert) != 2 || insert["email"] != "alice@example.com" || insert["username"] != upperName("alice") {
+		t.Errorf("Unexpected insert columns: %v", insert)
+	}
+
+	member.Id = 7
+	member.Age = 30
+	update := columnValues(reflect.ValueOf(member), mapUpdate)
+	if _, ok := update["id"]; ok || len(update) != 3 || update["age"] != 30 {
+		t.Errorf("Unexpected update columns: %v", update)
+	}
+
+	insert = columnValues(reflect.ValueOf(member), mapInsert)
+	if insert["id"] != int64(7) {
+		t.Errorf("Expected a set primary key to be inserted, got: %v", insert)
+	}
+
+	conditions := columnValues(reflect.ValueOf(Member{Id: 7}), mapConditions)
+	if len(conditions) != 1 || conditions["id"] != int64(7) {
+		t.Errorf("Unexpected condition columns: %v", conditions)
+	}
+}
+
+func TestStructFieldMapsPrefersShallowFields(t *testing.T) {
+	type Base struct {
+		Id   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	type Override struct {
+		*Base
+		Name string `db:"name"`
+	}
+
+	fields := structFieldMaps(reflect.TypeOf(Override{}))
+	if len(fields) != 2 || fields[0].column != "id" || len(fields[0].index) != 2 || fields[1].column != "name" || len(fields[1].index) != 1 {
+		t.Errorf("Unexpected fields: %+v", fields)
+	}
+
+	values := columnValues(reflect.ValueOf(Override{Name: "outer"}), mapInsert)
+	if len(values) != 1 || values["name"] != "outer" {
+		t.Errorf("Expected the nil embedded pointer to be skipped, got: %v", values)
+	}
+}
+
+func TestExecuteStructRoundTrip(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	member := Member{Contact: Contact{Email: "alice@example.com"}, Username: "alice", Age: 29, Password: "secret"}
+	id, err := builder.Table("users").Insert(member)
+	if err != nil {
+		t.Fatalf("insert failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	var stored Member
+	if err = builder.Table("users").Where("id", clause.OperatorEqual, id).Get(&stored); err != nil {
+		t.Fatal(err)
+	}
+
+	if stored.Id != id || stored.Email != member.Email || stored.Username != "ALICE" || stored.Age != 29 || stored.CreatedAt.IsZero() || stored.Password != "" {
+		t.Errorf("Unexpected stored member: %+v", stored)
+	}
+
+	stored.Age = 30
+	stored.CreatedAt = time.Time{}
+	if _, err = builder.Table("users").Where("id", clause.OperatorEqual, stored.Id).Update(stored); err != nil {
+		t.Fatalf("update failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	var updated Member
+	if err = builder.Table("users").WhereStruct(Member{Id: id}).Get(&updated); err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Age != 30 || updated.CreatedAt.IsZero() {
+		t.Errorf("Expected the age to be updated and created_at untouched, got: %+v", updated)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"Email":      "email",
+		"CreatedAt":  "created_at",
+		"UserID":     "user_id",
+		"HTTPStatus": "http_status",
+		"Address2":   "address2",
+		"Zip_Code":   "zip_code",
+	}
+
+	for name, expected := range tests {
+		if column := snakeCase(name); column != expected {
+			t.Errorf("snakeCase(%q): expected %q, got %q", name, expected, column)
+		}
+	}
+}
+
+func TestStructFieldMapsPrefersTaggedFields(t *testing.T) {
+	type Base struct {
+		Name string `db:"name"`
+	}
+	type Profile struct {
+		Base
+		Name     string
+		Nickname string
+	}
+
+	fields := structFieldMaps(reflect.TypeOf(Profile{}))
+	if len(fields) != 2 || fields[0].column != "name" || len(fields[0].index) != 2 || fields[1].column != "nickname" || !fields[1].inferred {
+		t.Errorf("Unexpected fields: %+v", fields)
+	}
+
+	values := columnValues(reflect.ValueOf(Profile{Base: Base{Name: "tagged"}, Name: "untagged", Nickname: "al"}), mapInsert)
+	if len(values) != 2 || values["name"] != "tagged" || values["nickname"] != "al" {
+		t.Errorf("Expected the tagged name and the untagged nickname to be written, got: %v", values)
+	}
+}
+
+func TestExecuteInsertUntaggedStruct(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	type NewUser struct {
+		Username string
+		Email    string
+		Age      int
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+	id, err := builder.Table("users").Insert(NewUser{Username: "alice", Email: "alice@example.com", Age: 29})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var user NewUser
+	if err = builder.Table("users").Where("id", clause.OperatorEqual, id).Get(&user); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Username != "alice" || user.Age != 29 {
+		t.Errorf("Unexpected user: %+v", user)
+	}
+
+	if _, err = builder.Table("users").Insert(struct{ note string }{}); !errors.Is(err, ErrNoColumns) {
+		t.Errorf("Expected ErrNoColumns, got: %v", err)
+	}
+
+	if _, err = builder.Table("users").Where("id", clause.OperatorEqual, id).Update(struct {
+		Id int64 `db:"id,pk"`
+	}{id}); !errors.Is(err, ErrNoColumns) {
+		t.Errorf("Expected ErrNoColumns, got: %v", err)
+	}
+}
+
+func TestExecuteLenientScan(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		ID        int64
+		Mail      string `db:"EMAIL"`
+		CreatedAt time.Time
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	var users []summary
+	if err = builder.Table("users").OrderBy("id", clause.OrderDirectionASC).Get(&users); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 10 || users[0].ID != 1 || users[0].Mail != "johndoe@example.com" || users[0].CreatedAt.IsZero() {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+
+	if unmapped := builder.UnmappedColumns(); !slices.Equal(unmapped, []string{"username", "age"}) {
+		t.Errorf("Expected username and age to be unmapped, got: %v", unmapped)
+	}
+
+	var user summary
+	if err = builder.Table("users").Select("id AS Id", "email").Where("id", clause.OperatorEqual, 2).Get(&user); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.ID != 2 || user.Mail == "" || len(builder.UnmappedColumns()) != 0 {
+		t.Errorf("Unexpected user: %+v, unmapped: %v", user, builder.UnmappedColumns())
+	}
+
+	strict := New(dialect.New("?", "`", "`"), dba, WithStrictScan(true))
+	err = strict.Table("users").Get(&users)
+
+	var unmappedErr *UnmappedColumnsError
+	if !errors.As(err, &unmappedErr) || !slices.Equal(unmappedErr.Columns, []string{"username", "age"}) {
+		t.Errorf("Expected an UnmappedColumnsError for username and age, got: %v", err)
+	}
+}
+
+func TestStructFieldMapsNestedStructs(t *testing.T) {
+	type Address struct {
+		City string `db:"city"`
+	}
+	type Company struct {
+		Name    string `db:"name"`
+		Address Address
+		Parent  *Company `db:"parent"`
+	}
+	type Employee struct {
+		Id      int64    `db:"id"`
+		Company *Company `db:"company"`
+	}
+
+	fields := structFieldMaps(reflect.TypeOf(Employee{}))
+
+	columns := []string{}
+	for _, field := range fields {
+		columns = append(columns, field.column)
+	}
+	if !slices.Equal(columns, []string{"id", "company.name", "company.address.city"}) {
+		t.Errorf("Unexpected columns: %v", columns)
+	}
+
+	values := columnValues(reflect.ValueOf(Employee{Id: 1, Company: &Company{Name: "acme"}}), mapInsert)
+	if len(values) != 1 || values["id"] != int64(1) {
+		t.Errorf("Expected nested fields not to be written, got: %v", values)
+	}
+}
+
+type audit struct {
+	Email string `db:"email"`
+}
+
+func TestExecuteScanSkipsUnexportedEmbeddedPointers(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	type row struct {
+		*audit
+		Id int64 `db:"id"`
+	}
+
+	fields := structFieldMaps(reflect.TypeOf(row{}))
+	if len(fields) != 1 || fields[0].column != "id" {
+		t.Errorf("Expected the unexported embedded pointer to be skipped, got: %+v", fields)
+	}
+
+	var rows []row
+	if err = New(dialect.New("?", "`", "`"), dba).Table("users").Select("id", "email").Get(&rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 10 || rows[0].Id != 1 || rows[0].audit != nil {
+		t.Errorf("Unexpected rows: %+v", rows)
+	}
+}
+
+func TestStructPlanCache(t *testing.T) {
+	type summary struct {
+		Id    int64 `db:"id"`
+		Email string
+	}
+
+	typ := reflect.TypeOf(summary{})
+	first := cachedStructPlan(typ, []string{"id", "email", "age"})
+	second := cachedStructPlan(typ, []string{"id", "email", "age"})
+	if &first.columns[0] != &second.columns[0] || !slices.Equal(first.unmapped, []string{"age"}) {
+		t.Errorf("Expected the plan to be reused, got: %+v and %+v", first, second)
+	}
+
+	other := cachedStructPlan(typ, []string{"email"})
+	if len(other.columns) != 1 || !slices.Equal(other.columns[0].index, []int{1}) {
+		t.Errorf("Expected a plan per column list, got: %+v", other)
+	}
+}
+
+type exportRow struct {
+	Id         int64     `db:"id"`
+	CustomerId int64     `db:"customer_id"`
+	Status     string    `db:"status"`
+	Total      float64   `db:"total"`
+	Note       string    `db:"note"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func seedExports(b *testing.B, count int) *sql.DB {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, err = dba.Exec(`
+		CREATE TABLE exports(
+			id integer primary key,
+			customer_id integer,
+			status TEXT,
+			total real,
+			note TEXT,
+			created_at datetime
+		)
+	`)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	rows := make([]exportRow, 0, count)
+	for i := 1; i <= count; i++ {
+		rows = append(rows, exportRow{Id: int64(i), CustomerId: int64(i % 50), Status: "paid", Total: float64(i) * 1.5, Note: "export", CreatedAt: time.Now()})
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba, WithLogging(false))
+	if _, err = builder.Table("exports").BulkInsert(rows, BulkInsertOptions{}); err != nil {
+		b.Fatal(err)
+	}
+
+	return dba
+}
+
+func BenchmarkScanAll(b *testing.B) {
+	dba := seedExports(b, 1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rows, err := dba.Query("SELECT * FROM exports")
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var exports []exportRow
+		if err = ScanAll(&exports, rows); err != nil {
+			b.Fatal(err)
+		}
+		rows.Close()
+	}
+}
+
+// BenchmarkScanAllUncached scans the rows as ScanAll did before fields were mapped and cached: the columns
+// are read and matched against the db tag of every field, for every row.
+func BenchmarkScanAllUncached(b *testing.B) {
+	dba := seedExports(b, 1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rows, err := dba.Query("SELECT * FROM exports")
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var exports []exportRow
+		for rows.Next() {
+			var row exportRow
+			if err = scanStructPerRow(&row, rows); err != nil {
+				b.Fatal(err)
+			}
+			exports = append(exports, row)
+		}
+		rows.Close()
+	}
+}
+
+func scanStructPerRow(d any, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	ref := reflect.TypeOf(d).Elem()
+
+	var dRefs []any
+	for _, column := range columns {
+		for i := 0; i < ref.NumField(); i++ {
+			if ref.Field(i).Tag.Get("db") == column {
+				dRefs = append(dRefs, reflect.ValueOf(d).Elem().Field(i).Addr().Interface())
+			}
+		}
+	}
+
+	return rows.Scan(dRefs...)
+}
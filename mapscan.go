@@ -0,0 +1,173 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MapScanMode selects how the values of a row are converted when it is scanned into a map.
+type MapScanMode int
+
+const (
+	// MapScanConverted converts int64 to int, float64 to float32, NULL to "" and byte slices to strings.
+	MapScanConverted MapScanMode = iota
+	// MapScanDriver keeps the values as the driver returns them: int64, float64, bool, string,
+	// time.Time, []byte, and nil for NULL.
+	MapScanDriver
+	// MapScanColumnTypes keeps the driver values and converts them by their database type through
+	// rows.ColumnTypes(): BOOL to bool, integer and floating point types to int64 and float64, DECIMAL
+	// and text types to string, and JSON to json.RawMessage. NULL stays nil.
+	MapScanColumnTypes
+)
+
+// WithMapScanMode sets how Get converts the values scanned into maps, MapScanConverted by default.
+func WithMapScanMode(mode MapScanMode) Option {
+	return func(s *SQLBuilder) {
+		s.mapScanMode = mode
+	}
+}
+
+// ScanMap scans the current row into the map pointed to by d, converting the values as MapScanConverted does.
+func ScanMap(d interface{}, rows *sql.Rows) error {
+	return (&rowScanner{}).scanMap(d, rows)
+}
+
+// ScanMapWithMode scans the current row into the map pointed to by d, converting the values as mode does.
+func ScanMapWithMode(d interface{}, rows *sql.Rows, mode MapScanMode) error {
+	return (&rowScanner{mapMode: mode}).scanMap(d, rows)
+}
+
+func (sc *rowScanner) scanMap(d interface{}, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	ref := reflect.TypeOf(d)
+
+	if ref.Kind() != reflect.Ptr {
+		return errors.New(fmt.Sprintf("The destination must be pointer, passed: %v", ref.Kind()))
+	}
+
+	ref = ref.Elem()
+
+	refKind := ref.Kind()
+
+	if refKind != reflect.Map {
+		return errors.New(fmt.Sprintf("model.ScanMap only accepts map kind: %v", refKind))
+	}
+
+	if sc.mapMode == MapScanColumnTypes && sc.columnTypes == nil {
+		if sc.columnTypes, err = rows.ColumnTypes(); err != nil {
+			return err
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		var v interface{}
+		values[i] = &v
+	}
+	if err := rows.Scan(values...); err != nil {
+		return err
+	}
+
+	m := reflect.ValueOf(d).Elem()
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(ref))
+	}
+
+	for i, column := range columns {
+		value := *(values[i].(*interface{}))
+		switch sc.mapMode {
+		case MapScanConverted:
+			value = convertedValue(value)
+		case MapScanColumnTypes:
+			value = columnTypeValue(sc.columnTypes[i], value)
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			v = reflect.Zero(ref.Elem())
+		}
+		m.SetMapIndex(reflect.ValueOf(column), v)
+	}
+
+	return nil
+}
+
+// convertedValue converts a driver value as MapScanConverted does.
+func convertedValue(value any) any {
+	switch v := value.(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return float32(v)
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case bool, string, time.Time:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// columnTypeValue converts a driver value according to the database type of its column, values that do not
+// convert are kept as they are.
+func columnTypeValue(ct *sql.ColumnType, value any) any {
+	if value == nil {
+		return nil
+	}
+
+	typeName, _, _ := strings.Cut(strings.ToUpper(ct.DatabaseTypeName()), "(")
+	typeName = strings.TrimSpace(typeName)
+
+	switch typeName {
+	case "BOOL", "BOOLEAN":
+		switch v := value.(type) {
+		case int64:
+			return v != 0
+		case []byte:
+			if b, err := strconv.ParseBool(string(v)); err == nil {
+				return b
+			}
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+	case "JSON", "JSONB":
+		switch v := value.(type) {
+		case []byte:
+			return json.RawMessage(v)
+		case string:
+			return json.RawMessage(v)
+		}
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "INT2", "INT4", "INT8", "SERIAL", "BIGSERIAL":
+		if v, ok := value.([]byte); ok {
+			if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+				return n
+			}
+		}
+	case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8", "DOUBLE PRECISION":
+		if v, ok := value.([]byte); ok {
+			if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+				return f
+			}
+		}
+	case "DECIMAL", "NUMERIC", "CHAR", "VARCHAR", "TEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT", "NCHAR", "NVARCHAR", "BPCHAR", "UUID", "ENUM":
+		if v, ok := value.([]byte); ok {
+			return string(v)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,119 @@
+package sqlbuilder
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func seedProducts(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE products(
+			id integer primary key,
+			price DECIMAL(10,2),
+			active BOOLEAN,
+			meta JSON,
+			image BLOB,
+			note TEXT
+		);
+		INSERT INTO products values(1, 1234567.89, 1, '{"color":"red"}', x'0102', NULL);
+	`)
+
+	return err
+}
+
+func TestExecuteScanMapModes(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedProducts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	var converted map[string]any
+	if err = New(dialect.New("?", "`", "`"), dba).Table("products").Get(&converted); err != nil {
+		t.Fatal(err)
+	}
+
+	if converted["id"] != 1 || converted["price"] != float32(1234567.89) || converted["note"] != "" || converted["image"] != "\x01\x02" {
+		t.Errorf("Unexpected converted row: %#v", converted)
+	}
+
+	var raw []map[string]any
+	if err = New(dialect.New("?", "`", "`"), dba, WithMapScanMode(MapScanDriver)).Table("products").Get(&raw); err != nil {
+		t.Fatal(err)
+	}
+
+	row := raw[0]
+	if row["id"] != int64(1) || row["price"] != 1234567.89 || row["meta"] != `{"color":"red"}` || row["note"] != nil {
+		t.Errorf("Unexpected driver row: %#v", row)
+	}
+
+	if image, ok := row["image"].([]byte); !ok || !bytes.Equal(image, []byte{1, 2}) {
+		t.Errorf("Expected the image to stay binary, got: %#v", row["image"])
+	}
+
+	var typed map[string]any
+	if err = New(dialect.New("?", "`", "`"), dba, WithMapScanMode(MapScanColumnTypes)).Table("products").Get(&typed); err != nil {
+		t.Fatal(err)
+	}
+
+	if typed["active"] != true || typed["note"] != nil {
+		t.Errorf("Unexpected typed row: %#v", typed)
+	}
+
+	if meta, ok := typed["meta"].(json.RawMessage); !ok || string(meta) != `{"color":"red"}` {
+		t.Errorf("Expected the meta to be json.RawMessage, got: %#v", typed["meta"])
+	}
+}
+
+func TestColumnTypeValue(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedProducts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := dba.Query("SELECT id, price, active, meta, note FROM products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// text protocol values, as MySQL returns them
+	tests := []struct {
+		column   int
+		value    any
+		expected any
+	}{
+		{0, []byte("42"), int64(42)},
+		{1, []byte("1234567.89"), "1234567.89"},
+		{2, []byte("1"), true},
+		{2, int64(0), false},
+		{4, []byte("memo"), "memo"},
+		{4, nil, nil},
+	}
+
+	for _, tt := range tests {
+		if value := columnTypeValue(types[tt.column], tt.value); value != tt.expected {
+			t.Errorf("%s %v: expected %#v, got %#v", types[tt.column].DatabaseTypeName(), tt.value, tt.expected, value)
+		}
+	}
+
+	if meta, ok := columnTypeValue(types[3], []byte(`[1]`)).(json.RawMessage); !ok || string(meta) != "[1]" {
+		t.Errorf("Expected JSON to convert to json.RawMessage, got: %#v", meta)
+	}
+}
@@ -1,18 +1,133 @@
 package sqlbuilder
 
 import (
-	"encoding/json"
+	"fmt"
+	"reflect"
 )
 
-func toMap(data, dst any) error {
-	jsonBytes, err := json.Marshal(data)
-	if err != nil {
-		return err
+// toDataMap returns the columns of a map, or of a struct through its db tags as selected by mode.
+func toDataMap(data any, mode mapMode) (map[string]any, error) {
+	if dataMap, ok := data.(map[string]any); ok {
+		return dataMap, nil
 	}
 
-	err = json.Unmarshal(jsonBytes, &dst)
+	v, err := structValue(data)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("sqlbuilder: expected a map[string]any or a struct, passed: %T", data)
+	}
+
+	return columnValues(v, mode), nil
+}
+
+// toSliceOfAny converts any slice or array, except byte slices, into []any.
+func toSliceOfAny(val any) ([]any, bool) {
+	if values, ok := val.([]any); ok {
+		return values, true
+	}
+
+	ref := reflect.ValueOf(val)
+	if ref.Kind() != reflect.Slice && ref.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	if ref.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	values := make([]any, ref.Len())
+	for i := range values {
+		values[i] = ref.Index(i).Interface()
+	}
+
+	return values, true
+}
+
+// defaultValue fills a column that a struct row leaves out, as an omitempty or pk field, while other rows of the
+// slice set it. It is written as DEFAULT, or as fallback where the dialect has no DEFAULT keyword in VALUES.
+type defaultValue struct {
+	fallback any
+}
+
+// toRows returns the rows of a []map[string]any, or of a slice of structs or struct pointers
+// mapped through their db tags like Insert does. The struct rows share their columns: an omitempty
+// or pk column is only left out when it is empty in every row, the rows leaving it empty get a defaultValue.
+func toRows(data any) ([]map[string]any, error) {
+	if rows, ok := data.([]map[string]any); ok {
+		return rows, nil
+	}
+
+	ref := reflect.ValueOf(data)
+	if ref.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("sqlbuilder: expected a slice of maps or structs, passed: %T", data)
+	}
+
+	elem := ref.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sqlbuilder: expected a slice of maps or structs, passed: %T", data)
+	}
+
+	rows := make([]map[string]any, 0, ref.Len())
+	structs := make([]reflect.Value, 0, ref.Len())
+	columns := map[string]bool{}
+	for i := 0; i < ref.Len(); i++ {
+		row := ref.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				return nil, fmt.Errorf("sqlbuilder: row %d is a nil pointer", i)
+			}
+			row = row.Elem()
+		}
+
+		values := columnValues(row, mapInsert)
+		for column := range values {
+			columns[column] = true
+		}
+
+		rows = append(rows, values)
+		structs = append(structs, row)
+	}
+
+	for _, field := range structFieldMaps(elem) {
+		if !columns[field.column] {
+			continue
+		}
+
+		for i, row := range rows {
+			if _, ok := row[field.column]; ok {
+				continue
+			}
+
+			// the pk falls back to NULL so that the database assigns it
+			var fallback any
+			if fv, ok := fieldByIndex(structs[i], field.index, false); ok && !field.pk {
+				fallback = fv.Interface()
+			}
+			row[field.column] = defaultValue{fallback: fallback}
+		}
+	}
+
+	return rows, nil
+}
+
+// checkColumns reports an ErrColumnMismatch when a row does not have the same columns as the first one.
+func checkColumns(rows []map[string]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	for i, row := range rows[1:] {
+		if len(row) != len(rows[0]) {
+			return fmt.Errorf("%w: row %d has %d columns, row 0 has %d", ErrColumnMismatch, i+1, len(row), len(rows[0]))
+		}
+
+		for column := range rows[0] {
+			if _, ok := row[column]; !ok {
+				return fmt.Errorf("%w: row %d has no %q column", ErrColumnMismatch, i+1, column)
+			}
+		}
 	}
 
 	return nil
@@ -3,9 +3,18 @@ package pkg
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 func ColumnSplitter(s, leftQuote, rightQuote string) string {
+	if i := strings.Index(strings.ToLower(s), " as "); i > 0 {
+		// only a plain, possibly qualified, column is quoted in front of its alias
+		if column := strings.TrimSpace(s[:i]); isColumnName(column) {
+			return ColumnSplitter(column, leftQuote, rightQuote) + " AS " + strings.TrimSpace(s[i+4:])
+		}
+		return s
+	}
+
 	if strings.Contains(s, ".") {
 		parts := strings.SplitN(s, ".", 2)
 		if parts[1] == "*" {
@@ -15,9 +24,34 @@ func ColumnSplitter(s, leftQuote, rightQuote string) string {
 		return fmt.Sprintf("%s%s%s.%s%s%s", leftQuote, parts[0], rightQuote, leftQuote, parts[1], rightQuote)
 	}
 
-	if s == "*" || strings.Contains(strings.ToLower(s), " as ") {
+	if s == "*" {
 		return s
 	}
 
 	return fmt.Sprintf("%s%s%s", leftQuote, s, rightQuote)
 }
+
+// isColumnName reports whether s is a column name, optionally qualified by its table.
+func isColumnName(s string) bool {
+	for _, part := range strings.SplitN(s, ".", 2) {
+		if !isIdentifier(part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,40 @@
+package sqlbuilder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Pluck selects a single column and scans its values into the slice pointed to by dest, e.g. *[]int64.
+func (b *SQLBuilder) Pluck(column string, dest any) error {
+	return b.Select(column).Get(dest)
+}
+
+// PluckMap selects two columns and scans them into the map pointed to by dest, keyed by the values of
+// keyColumn, e.g. *map[int64]string. The map is replaced, later rows win on duplicate keys.
+func (b *SQLBuilder) PluckMap(keyColumn string, valueColumn string, dest any) error {
+	m := reflect.ValueOf(dest)
+	if m.Kind() != reflect.Ptr || m.Elem().Kind() != reflect.Map {
+		return fmt.Errorf("sqlbuilder: PluckMap expects a pointer to a map, passed: %T", dest)
+	}
+	m = m.Elem()
+
+	rows, err := b.Select(keyColumn, valueColumn).runQuery(context.Background())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	m.Set(reflect.MakeMap(m.Type()))
+	for rows.Next() {
+		key := reflect.New(m.Type().Key())
+		value := reflect.New(m.Type().Elem())
+		if err = rows.Scan(key.Interface(), value.Interface()); err != nil {
+			return err
+		}
+		m.SetMapIndex(key.Elem(), value.Elem())
+	}
+
+	return rows.Err()
+}
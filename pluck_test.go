@@ -0,0 +1,98 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestExecutePluck(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	ids := []int64{99}
+	if err = builder.Table("users").Where("age", clause.OperatorGreaterThan, 30).OrderBy("id", clause.OrderDirectionASC).Pluck("id", &ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) == 0 || ids[0] != 1 {
+		t.Errorf("Unexpected ids: %v", ids)
+	}
+
+	var usernames []string
+	if err = builder.Table("users").Select("username").OrderBy("id", clause.OrderDirectionASC).Limit(2).Get(&usernames); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(usernames) != 2 || usernames[0] != "johndoe" || usernames[1] != "daniel" {
+		t.Errorf("Unexpected usernames: %v", usernames)
+	}
+
+	var createdAt []time.Time
+	if err = builder.Table("users").Pluck("created_at", &createdAt); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(createdAt) != 10 || createdAt[0].IsZero() {
+		t.Errorf("Unexpected created_at: %v", createdAt)
+	}
+
+	if err = builder.Table("users").Select("id", "username").Get(&ids); err == nil {
+		t.Error("Expected an error scanning two columns into a slice of int64")
+	}
+
+	var users []*User
+	if err = builder.Table("users").Where("id", clause.OperatorLessThanEqual, 2).Get(&users); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 || users[1].Username != "daniel" {
+		t.Errorf("Unexpected users: %v", users)
+	}
+}
+
+func TestExecutePluckMap(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	var usernames map[int64]string
+	if err = builder.Table("users").Where("id", clause.OperatorLessThanEqual, 3).PluckMap("id", "username", &usernames); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(usernames) != 3 || usernames[1] != "johndoe" || usernames[3] != "samuel" {
+		t.Errorf("Unexpected usernames: %v", usernames)
+	}
+
+	var ages map[string]any
+	if err = builder.Table("users").Where("username", clause.OperatorEqual, "daniel").PluckMap("username", "age", &ages); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ages) != 1 || ages["daniel"] != int64(32) {
+		t.Errorf("Unexpected ages: %v", ages)
+	}
+
+	if err = builder.Table("users").PluckMap("id", "username", usernames); err == nil {
+		t.Error("Expected an error plucking into a map that is not a pointer")
+	}
+}
@@ -0,0 +1,120 @@
+package sqlbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+const DefaultPrimaryKey = "id"
+
+var ErrReturningUnsupported = errors.New("sqlbuilder: RETURNING is not supported by this dialect")
+var ErrNoInsertKey = errors.New("sqlbuilder: no integer key for Insert to read back")
+
+// WithPrimaryKey sets the primary key column, id by default, through which UPDATE and DELETE select their
+// rows when they cannot order or limit them natively. It is also the column Insert reads back with RETURNING
+// on PostgreSQL for data without a pk tagged field. An empty column selects the rows by ctid or rowid.
+func WithPrimaryKey(column string) Option {
+	return func(s *SQLBuilder) {
+		s.primaryKey = column
+	}
+}
+
+// insertKey returns the column Insert reads back on PostgreSQL: the single column selected with Returning,
+// the pk tagged field of a struct, or the primary key. As the key is scanned into an int64, a pk field
+// that is not an integer returns ErrNoInsertKey before anything is written.
+func (s *SQLBuilder) insertKey(data any) (string, error) {
+	switch len(s.returning) {
+	case 0:
+	case 1:
+		return s.returning[0], nil
+	default:
+		return "", fmt.Errorf("%w: Insert reads back a single column, got %d, use InsertReturning", ErrNoInsertKey, len(s.returning))
+	}
+
+	if v, err := structValue(data); err == nil {
+		for _, field := range structFieldMaps(v.Type()) {
+			if !field.pk || field.nested {
+				continue
+			}
+
+			if !isIntegerType(v.Type().FieldByIndex(field.index).Type) {
+				return "", fmt.Errorf("%w: the %q key is not an integer, use InsertReturning", ErrNoInsertKey, field.column)
+			}
+
+			return field.column, nil
+		}
+	}
+
+	if s.primaryKey == "" {
+		return "", fmt.Errorf("%w: tag a pk field, set WithPrimaryKey or use InsertReturning", ErrNoInsertKey)
+	}
+
+	return s.primaryKey, nil
+}
+
+func isIntegerType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+// Returning selects the columns returned by the next INSERT, UPDATE or DELETE.
+// It is supported on PostgreSQL and SQLite 3.35 or later.
+func (s *SQLBuilder) Returning(columns ...string) *SQLBuilder {
+	s.returning = columns
+	return s
+}
+
+func (s *SQLBuilder) returningClause() string {
+	if len(s.returning) == 0 {
+		return ""
+	}
+
+	columns := make([]string, 0, len(s.returning))
+	for _, column := range s.returning {
+		if column == "*" {
+			columns = append(columns, column)
+			continue
+		}
+		columns = append(columns, s.QuoteColumn(column))
+	}
+
+	return " RETURNING " + strings.Join(columns, ",")
+}
+
+// queryReturning runs the prepared statement and scans the returned rows into dest.
+func (s *SQLBuilder) queryReturning(dest any) error {
+	if s.Dialect.GetName() == dialect.MySQL {
+		return ErrReturningUnsupported
+	}
+
+	if len(s.returning) == 0 {
+		s.returning = []string{"*"}
+		s.rawStatement += s.returningClause()
+	}
+
+	rows, err := s.runQuery(context.Background())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if err = s.scan(dest, rows); err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,131 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestReturningStatementPG(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db)
+
+	builder.Table("users").Returning("id", "created_at")
+	builder.prepareInsert([]map[string]any{{"username": "alice", "age": 29}})
+
+	if sql := builder.GetSql(); sql != `INSERT INTO "users"("age","username") VALUES($1,$2) RETURNING "id","created_at"` {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder.Table("users").Where("id", clause.OperatorEqual, 1).Returning("id")
+	if err := builder.prepareUpdate(map[string]any{"age": 30}); err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != `UPDATE "users" SET "age" = $2 WHERE "id" = $1 RETURNING "id"` {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestInsertKeyPG(t *testing.T) {
+	builder := New(dialect.NewPostgres(), db)
+
+	type Token struct {
+		Id   string `db:"id,pk"`
+		Name string `db:"name"`
+	}
+
+	tests := []struct {
+		builder  *SQLBuilder
+		data     any
+		expected string
+	}{
+		{builder, Member{}, "id"},
+		{builder, User{}, "id"},
+		{builder, map[string]any{"username": "alice"}, "id"},
+		{New(dialect.NewPostgres(), db, WithPrimaryKey("user_id")), User{}, "user_id"},
+		{New(dialect.NewPostgres(), db, WithPrimaryKey("user_id")), &Member{}, "id"},
+		{New(dialect.NewPostgres(), db).Returning("user_id"), Member{}, "user_id"},
+	}
+
+	for _, tt := range tests {
+		key, err := tt.builder.insertKey(tt.data)
+		if err != nil || key != tt.expected {
+			t.Errorf("%T: expected the key %q, got %q, %v", tt.data, tt.expected, key, err)
+		}
+	}
+
+	failing := []struct {
+		builder *SQLBuilder
+		data    any
+	}{
+		{New(dialect.NewPostgres(), db, WithPrimaryKey("")).Table("users"), map[string]any{"username": "alice"}},
+		{builder.Table("tokens"), Token{Id: "f47ac10b", Name: "api"}},
+		{New(dialect.NewPostgres(), db).Table("users").Returning("id", "created_at"), User{}},
+	}
+
+	for _, tt := range failing {
+		if _, err := tt.builder.Insert(tt.data); !errors.Is(err, ErrNoInsertKey) {
+			t.Errorf("%T: expected ErrNoInsertKey, got: %v", tt.data, err)
+		}
+	}
+}
+
+func TestReturningUnsupportedOnMySQL(t *testing.T) {
+	builder := New(dialect.NewMySQL(), db)
+
+	var ids []int64
+	err := builder.Table("users").Where("id", clause.OperatorEqual, 1).Returning("id").DeleteReturning(&ids)
+	if !errors.Is(err, ErrReturningUnsupported) {
+		t.Fatalf("Expected ErrReturningUnsupported, got: %v", err)
+	}
+}
+
+func TestExecuteReturning(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seed(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba)
+
+	var user User
+	err = builder.Table("users").InsertReturning(map[string]any{
+		"username": "alice",
+		"email":    "alice@example.com",
+		"age":      29,
+	}, &user)
+	if err != nil {
+		t.Fatalf("insert returning failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if user.Id != 11 || user.CreatedAt.IsZero() {
+		t.Errorf("Expected the inserted row with its defaults, got: %v", user)
+	}
+
+	var updated []map[string]any
+	err = builder.Table("users").Where("age", clause.OperatorGreaterThan, 35).Returning("id", "age").UpdateReturning(map[string]any{"age": 50}, &updated)
+	if err != nil {
+		t.Fatalf("update returning failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if len(updated) != 1 || updated[0]["age"] != 50 {
+		t.Errorf("Unexpected updated rows: %v", updated)
+	}
+
+	deleted := map[string]any{}
+	err = builder.Table("users").Where("username", clause.OperatorEqual, "alice").Returning("email").DeleteReturning(&deleted)
+	if err != nil {
+		t.Fatalf("delete returning failed: %v, sql: %s", err, builder.GetSql())
+	}
+
+	if deleted["email"] != "alice@example.com" {
+		t.Errorf("Unexpected deleted row: %v", deleted)
+	}
+}
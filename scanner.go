@@ -5,12 +5,188 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"time"
+	"slices"
+	"strings"
+	"sync"
 )
 
+// UnmappedColumnsError is returned by strict scanning when result columns have no matching struct field.
+type UnmappedColumnsError struct {
+	Type    reflect.Type
+	Columns []string
+}
+
+func (e *UnmappedColumnsError) Error() string {
+	return fmt.Sprintf("sqlbuilder: columns %s have no matching field in %s", strings.Join(e.Columns, ", "), e.Type)
+}
+
+// WithStrictScan makes Get fail with an UnmappedColumnsError, instead of discarding the columns,
+// when the result has columns without a matching struct field.
+func WithStrictScan(enabled bool) Option {
+	return func(s *SQLBuilder) {
+		s.strictScan = enabled
+	}
+}
+
+// UnmappedColumns returns the columns of the last Get that had no matching struct field and were discarded.
+func (s *SQLBuilder) UnmappedColumns() []string {
+	return slices.Clone(s.unmappedColumns)
+}
+
+// scan scans rows into d and records the unmapped columns on the builder.
+func (s *SQLBuilder) scan(d any, rows *sql.Rows) error {
+	sc := &rowScanner{strict: s.strictScan, mapMode: s.mapScanMode}
+	err := sc.scanRows(d, rows)
+	s.unmappedColumns = sc.unmapped
+
+	return err
+}
+
+// rowScanner scans rows into structs, discarding the columns without a matching field unless strict is set,
+// and into maps, converting the values as mapMode does.
+type rowScanner struct {
+	strict      bool
+	unmapped    []string
+	mapMode     MapScanMode
+	columnTypes []*sql.ColumnType
+}
+
+// structPlan holds, for each result column, the struct field it is scanned into.
+type structPlan struct {
+	columns  []planColumn
+	unmapped []string
+}
+
+// planColumn locates the field of a column, index is nil when the column is discarded. Fields reached
+// through a pointer are scanned into a holder of type holder first, so the pointer is only allocated
+// when one of its columns is not NULL.
+type planColumn struct {
+	index  []int
+	holder reflect.Type
+}
+
+// planKey identifies the plan of a struct type for a list of result columns.
+type planKey struct {
+	t       reflect.Type
+	columns string
+}
+
+// planCache holds the structPlan of each planKey, so the fields are matched once per type and columns
+// instead of once per query. It is never evicted and keeps a plan per distinct column list scanned into
+// a type, which stays small for the queries of an application but grows with generated column lists.
+var planCache sync.Map
+
+func cachedStructPlan(t reflect.Type, columns []string) structPlan {
+	key := planKey{t: t, columns: strings.Join(columns, "\x00")}
+	if plan, ok := planCache.Load(key); ok {
+		return plan.(structPlan)
+	}
+
+	plan, _ := planCache.LoadOrStore(key, newStructPlan(t, structFieldMaps(t), columns))
+	return plan.(structPlan)
+}
+
+// newStructPlan matches the columns to the fields of t, exactly first and then case-insensitively,
+// with the columns of nested structs also matched by their prefix__column alias.
+func newStructPlan(t reflect.Type, fields []fieldMap, columns []string) structPlan {
+	exact := map[string][]int{}
+	folded := map[string][]int{}
+	for _, field := range fields {
+		exact[field.column] = field.index
+		if _, ok := folded[foldColumn(field.column)]; !ok || !field.inferred {
+			folded[foldColumn(field.column)] = field.index
+		}
+	}
+
+	plan := structPlan{columns: make([]planColumn, len(columns))}
+	for i, column := range columns {
+		index, ok := exact[column]
+		if !ok {
+			index, ok = folded[foldColumn(column)]
+		}
+		if !ok {
+			plan.unmapped = append(plan.unmapped, column)
+			continue
+		}
+
+		plan.columns[i].index = index
+		if fieldType, throughPointer := fieldPath(t, index); throughPointer {
+			plan.columns[i].holder = reflect.PointerTo(fieldType)
+		}
+	}
+
+	return plan
+}
+
+func foldColumn(column string) string {
+	return strings.ToLower(strings.ReplaceAll(column, "__", "."))
+}
+
+// fieldPath returns the type of the field at index and whether a pointer is dereferenced to reach it.
+func fieldPath(t reflect.Type, index []int) (reflect.Type, bool) {
+	throughPointer := false
+	for _, idx := range index {
+		if t.Kind() == reflect.Ptr {
+			throughPointer = true
+			t = t.Elem()
+		}
+		t = t.Field(idx).Type
+	}
+
+	return t, throughPointer
+}
+
+// discardColumn is the scan destination of unmapped columns.
+type discardColumn struct{}
+
+func (discardColumn) Scan(any) error {
+	return nil
+}
+
+// scanRows scans the first row into a struct, map or scalar destination, or every row into a slice.
+func (sc *rowScanner) scanRows(d any, rows *sql.Rows) error {
+	ref := reflect.TypeOf(d)
+	if ref.Kind() == reflect.Ptr {
+		ref = ref.Elem()
+	}
+
+	if ref.Kind() == reflect.Ptr && isNestedStruct(ref.Elem()) {
+		val := reflect.ValueOf(d).Elem()
+		if val.IsNil() {
+			val.Set(reflect.New(ref.Elem()))
+		}
+		return sc.scanRows(val.Interface(), rows)
+	}
+
+	switch {
+	case isNestedStruct(ref):
+		if rows.Next() {
+			return sc.scanStruct(d, rows)
+		}
+	case ref.Kind() == reflect.Map:
+		if rows.Next() {
+			return sc.scanMap(d, rows)
+		}
+	case ref.Kind() == reflect.Slice && ref.Elem().Kind() != reflect.Uint8:
+		return sc.scanAll(d, rows)
+	default:
+		if rows.Next() {
+			return rows.Scan(d)
+		}
+	}
+
+	return nil
+}
+
+// ScanStruct scans the current row into the struct pointed to by d. Columns are matched to db tags,
+// or to the snake_case name of untagged fields, case-insensitively; columns without a field are discarded.
+// Nested struct fields are filled from prefixed columns, e.g. company.name or company__name, and nested
+// pointers are left nil when all their columns are NULL.
 func ScanStruct(d interface{}, rows *sql.Rows) error {
-	columns, err := rows.Columns()
+	return (&rowScanner{}).scanStruct(d, rows)
+}
 
+func (sc *rowScanner) scanStruct(d interface{}, rows *sql.Rows) error {
 	ref := reflect.TypeOf(d)
 	if ref.Kind() != reflect.Ptr {
 		return errors.New(fmt.Sprintf("The destination must be pointer, passed: %v", ref.Kind()))
@@ -23,27 +199,85 @@ func ScanStruct(d interface{}, rows *sql.Rows) error {
 		return errors.New(fmt.Sprintf("model.ScanRow only accepts struct kind: %v", refKind))
 	}
 
-	if refKind == reflect.Struct {
-		var dRefs []interface{}
-		for _, column := range columns {
-			for i := 0; i < ref.NumField(); i++ {
-				f := ref.Field(i)
+	plan, err := sc.plan(ref, rows)
+	if err != nil {
+		return err
+	}
+
+	return plan.scan(reflect.ValueOf(d).Elem(), rows)
+}
 
-				if f.Tag.Get("db") == column {
-					dRefs = append(dRefs, reflect.ValueOf(d).Elem().Field(i).Addr().Interface())
-				}
+// plan matches the result columns to the fields of t, failing on unmapped columns when strict.
+func (sc *rowScanner) plan(t reflect.Type, rows *sql.Rows) (structPlan, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return structPlan{}, err
+	}
+
+	plan := cachedStructPlan(t, columns)
+	sc.unmapped = plan.unmapped
+	if sc.strict && len(plan.unmapped) > 0 {
+		return structPlan{}, &UnmappedColumnsError{Type: t, Columns: slices.Clone(plan.unmapped)}
+	}
+
+	return plan, nil
+}
+
+func (p structPlan) scan(val reflect.Value, rows *sql.Rows) error {
+	dRefs := make([]interface{}, len(p.columns))
+	holders := make([]reflect.Value, len(p.columns))
+	for i, column := range p.columns {
+		switch {
+		case column.index == nil:
+			dRefs[i] = discardColumn{}
+		case column.holder != nil:
+			holders[i] = reflect.New(column.holder)
+			dRefs[i] = holders[i].Interface()
+		default:
+			field, ok := fieldByIndex(val, column.index, true)
+			if !ok {
+				return p.unsettable(i, rows)
 			}
+			dRefs[i] = field.Addr().Interface()
 		}
-		if err = rows.Scan(dRefs...); err != nil {
-			return err
+	}
+
+	if err := rows.Scan(dRefs...); err != nil {
+		return err
+	}
+
+	for i, holder := range holders {
+		if !holder.IsValid() || holder.Elem().IsNil() {
+			continue
 		}
+
+		field, ok := fieldByIndex(val, p.columns[i].index, true)
+		if !ok {
+			return p.unsettable(i, rows)
+		}
+		field.Set(holder.Elem().Elem())
 	}
 
 	return nil
+}
 
+// unsettable reports the i-th column, whose field sits behind a nil pointer that cannot be allocated.
+func (p structPlan) unsettable(i int, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("sqlbuilder: cannot set the field of column %q through a nil pointer", columns[i])
 }
 
+// ScanAll scans every row into the slice pointed to by d: structs and struct pointers as ScanStruct does,
+// maps as ScanMap does, and scalars such as int64 or string from a single column result.
 func ScanAll(d interface{}, rows *sql.Rows) error {
+	return (&rowScanner{}).scanAll(d, rows)
+}
+
+func (sc *rowScanner) scanAll(d interface{}, rows *sql.Rows) error {
 	ref := reflect.TypeOf(d)
 	val := reflect.ValueOf(d)
 
@@ -64,77 +298,54 @@ func ScanAll(d interface{}, rows *sql.Rows) error {
 	base := ref.Elem()
 	val.SetLen(0)
 
-	for rows.Next() {
-		if base.Kind() == reflect.Struct {
-			v := reflect.New(base)
-			if err := ScanStruct(v.Interface(), rows); err != nil {
+	switch {
+	case isNestedStruct(base), base.Kind() == reflect.Ptr && isNestedStruct(base.Elem()):
+		elem := base
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		plan, err := sc.plan(elem, rows)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			v := reflect.New(elem)
+			if err := plan.scan(v.Elem(), rows); err != nil {
 				return err
 			}
-			val.Set(reflect.Append(val, reflect.Indirect(v)))
-		}
 
-		if base.Kind() == reflect.Map {
+			if base.Kind() == reflect.Ptr {
+				val.Set(reflect.Append(val, v))
+				continue
+			}
+			val.Set(reflect.Append(val, v.Elem()))
+		}
+	case base.Kind() == reflect.Map:
+		for rows.Next() {
 			var m = make(map[string]interface{})
-			if err := ScanMap(&m, rows); err != nil {
+			if err := sc.scanMap(&m, rows); err != nil {
 				return err
 			}
 			val.Set(reflect.Append(val, reflect.ValueOf(m)))
 		}
-	}
-
-	return nil
-}
-
-func ScanMap(d interface{}, rows *sql.Rows) error {
-	columns, err := rows.Columns()
-	if err != nil {
-		return err
-	}
-
-	ref := reflect.TypeOf(d)
-
-	if ref.Kind() != reflect.Ptr {
-		return errors.New(fmt.Sprintf("The destination must be pointer, passed: %v", ref.Kind()))
-	}
-
-	ref = ref.Elem()
-
-	refKind := ref.Kind()
-
-	if refKind != reflect.Map {
-		return errors.New(fmt.Sprintf("model.ScanMap only accepts map kind: %v", refKind))
-	}
+	default:
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
 
-	values := make([]interface{}, len(columns))
-	for i := range values {
-		var v interface{}
-		values[i] = &v
-	}
-	if err := rows.Scan(values...); err != nil {
-		return err
-	}
+		if len(columns) != 1 {
+			return fmt.Errorf("sqlbuilder: scanning into %s needs a single column, got: %d", ref, len(columns))
+		}
 
-	for i, column := range columns {
-		value := reflect.ValueOf(values[i]).Elem()
-		switch value.Elem().Kind() {
-		case reflect.Int64:
-			reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(int(value.Interface().(int64))))
-		case reflect.Float64:
-			reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(float32(value.Interface().(float64))))
-		case reflect.Bool:
-			reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(value.Interface().(bool)))
-		case reflect.Invalid:
-			reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(""))
-		case reflect.String:
-			reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(value.Interface().(string)))
-		default:
-			if value.Elem().Kind() != reflect.Slice {
-				if value.Elem().Type().Name() == "Time" {
-					reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(value.Interface().(time.Time)))
-					continue
-				}
+		for rows.Next() {
+			v := reflect.New(base)
+			if err := rows.Scan(v.Interface()); err != nil {
+				return err
 			}
-			reflect.ValueOf(d).Elem().SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(string(value.Interface().([]byte))))
+			val.Set(reflect.Append(val, v.Elem()))
 		}
 	}
 
@@ -0,0 +1,68 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrUnknownScope = errors.New("sqlbuilder: unknown scope")
+
+// ScopeFunc is a reusable query fragment. Besides where clauses it may add joins, ordering or anything else the Builder offers.
+type ScopeFunc func(b Builder) *SQLBuilder
+
+var (
+	scopesMu sync.RWMutex
+	scopes   = map[string]ScopeFunc{}
+)
+
+// RegisterScope makes a scope available to ApplyScope under name.
+// It panics if name is already registered or scope is nil.
+func RegisterScope(name string, scope ScopeFunc) {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	if scope == nil {
+		panic("sqlbuilder: RegisterScope scope is nil")
+	}
+
+	if _, ok := scopes[name]; ok {
+		panic(fmt.Sprintf("sqlbuilder: RegisterScope called twice for scope %q", name))
+	}
+
+	scopes[name] = scope
+}
+
+func lookupScope(name string) (ScopeFunc, bool) {
+	scopesMu.RLock()
+	defer scopesMu.RUnlock()
+
+	scope, ok := scopes[name]
+	return scope, ok
+}
+
+// Scope applies the given scopes to the builder in order.
+func (s *SQLBuilder) Scope(scopes ...ScopeFunc) *SQLBuilder {
+	b := s
+	for _, scope := range scopes {
+		b = scope(b)
+	}
+
+	return b
+}
+
+// ApplyScope applies scopes registered with RegisterScope in order.
+// A name that has not been registered makes the query fail with ErrUnknownScope.
+func (s *SQLBuilder) ApplyScope(names ...string) *SQLBuilder {
+	b := s
+	for _, name := range names {
+		scope, ok := lookupScope(name)
+		if !ok {
+			b.err = fmt.Errorf("%w %q", ErrUnknownScope, name)
+			return b
+		}
+		b = scope(b)
+	}
+
+	return b
+}
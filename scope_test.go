@@ -0,0 +1,59 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+// unregisterScope removes a registered scope, so tests can register theirs again.
+func unregisterScope(name string) {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	delete(scopes, name)
+}
+
+func adults(b Builder) *SQLBuilder {
+	return b.Where("age", clause.OperatorGreatherThanEqual, 18)
+}
+
+func withRole(role string) ScopeFunc {
+	return func(b Builder) *SQLBuilder {
+		return b.
+			Join("user_roles", "user_roles.user_id", clause.OperatorEqual, "users.id").
+			Join("roles", "roles.id", clause.OperatorEqual, "user_roles.role_id").
+			Where("roles.name", clause.OperatorEqual, role)
+	}
+}
+
+func TestScope(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db)
+	builder.Table("users").Scope(adults, withRole("admin"))
+
+	expected := "SELECT * FROM `users` INNER JOIN `user_roles` ON `user_roles`.`user_id` = `users`.`id` INNER JOIN `roles` ON `roles`.`id` = `user_roles`.`role_id` WHERE `age` >= ? AND `roles`.`name` = ?"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+}
+
+func TestApplyScope(t *testing.T) {
+	RegisterScope("test.latest", func(b Builder) *SQLBuilder {
+		return b.OrderBy("created_at", clause.OrderDirectionDESC).Scope(adults)
+	})
+	t.Cleanup(func() { unregisterScope("test.latest") })
+
+	builder := New(dialect.New("?", "`", "`"), db)
+	builder.Table("users").ApplyScope("test.latest")
+
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users` WHERE `age` >= ? ORDER BY created_at DESC" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	var users []User
+	if err := builder.Table("users").ApplyScope("test.unknown").Get(&users); !errors.Is(err, ErrUnknownScope) {
+		t.Fatalf("Expected ErrUnknownScope, got: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type trashedMode int
+
+const (
+	withoutTrashed trashedMode = iota
+	withTrashed
+	onlyTrashed
+)
+
+const DefaultSoftDeleteColumn = "deleted_at"
+
+var ErrSoftDeleteDisabled = errors.New("sqlbuilder: soft deletes are not enabled for this table")
+
+// WithSoftDeletes enables soft deletes on the given tables using the deleted_at column.
+func WithSoftDeletes(tables ...string) Option {
+	return func(s *SQLBuilder) {
+		for _, table := range tables {
+			WithSoftDeleteColumn(table, DefaultSoftDeleteColumn)(s)
+		}
+	}
+}
+
+// WithSoftDeleteColumn enables soft deletes on table using a custom timestamp column.
+func WithSoftDeleteColumn(table string, column string) Option {
+	return func(s *SQLBuilder) {
+		if s.softDeletes == nil {
+			s.softDeletes = map[string]string{}
+		}
+		s.softDeletes[table] = column
+	}
+}
+
+// WithTrashed includes soft deleted rows in the current query.
+func (s *SQLBuilder) WithTrashed() *SQLBuilder {
+	s.trashed = withTrashed
+	return s
+}
+
+// OnlyTrashed restricts the current query to soft deleted rows.
+func (s *SQLBuilder) OnlyTrashed() *SQLBuilder {
+	s.trashed = onlyTrashed
+	return s
+}
+
+// Restore clears the deletion timestamp of the soft deleted rows matching the query.
+func (s *SQLBuilder) Restore() (sql.Result, error) {
+	column, ok := s.softDeleteColumn()
+	if !ok {
+		return nil, ErrSoftDeleteDisabled
+	}
+
+	s.trashed = onlyTrashed
+	return s.Update(map[string]any{column: nil})
+}
+
+// ForceDelete permanently deletes the matching rows, trashed or not.
+func (s *SQLBuilder) ForceDelete() (sql.Result, error) {
+	s.trashed = withTrashed
+	if err := s.prepareForceDelete(); err != nil {
+		return nil, err
+	}
+
+	return s.Exec()
+}
+
+func (s *SQLBuilder) softDeleteColumn() (string, bool) {
+	column, ok := s.softDeletes[s.table]
+	return column, ok
+}
@@ -0,0 +1,115 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func seedPosts(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE posts(
+			id integer primary key,
+			title TEXT,
+			views integer default 0,
+			deleted_at datetime
+		);
+		INSERT INTO posts values(1, 'first', 10, null);
+		INSERT INTO posts values(2, 'second', 20, null);
+		INSERT INTO posts values(3, 'third', 30, '2023-01-01 10:00:00');
+	`)
+
+	return err
+}
+
+func TestSoftDeleteFiltersTrashedRows(t *testing.T) {
+	builder := New(dialect.New("?", "`", "`"), db, WithSoftDeletes("posts"))
+
+	builder.Table("posts").Where("id", clause.OperatorEqual, 1).OrWhere("id", clause.OperatorEqual, 2)
+	expected := "SELECT * FROM `posts` WHERE (`id` = ? OR `id` = ?) AND `posts`.`deleted_at` IS NULL"
+	if sql := builder.GetSql(); sql != expected {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder.Table("posts").OnlyTrashed()
+	if sql := builder.GetSql(); sql != "SELECT * FROM `posts` WHERE `posts`.`deleted_at` IS NOT NULL" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder.Table("posts").WithTrashed()
+	if sql := builder.GetSql(); sql != "SELECT * FROM `posts`" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	builder.Table("users")
+	if sql := builder.GetSql(); sql != "SELECT * FROM `users`" {
+		t.Fatalf("Expected tables without soft deletes to be untouched, got: %s", sql)
+	}
+}
+
+func TestExecuteSoftDelete(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = seedPosts(dba); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := New(dialect.New("?", "`", "`"), dba, WithSoftDeletes("posts"))
+
+	count, err := builder.Table("posts").Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("Expected count to be %d, but got: %d", 2, count)
+	}
+
+	_, err = builder.Table("posts").Where("id", clause.OperatorEqual, 1).Delete()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "UPDATE `posts` SET `deleted_at` = ? WHERE `id` = ? AND `posts`.`deleted_at` IS NULL" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	count, err = builder.Table("posts").OnlyTrashed().Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("Expected trashed count to be %d, but got: %d", 2, count)
+	}
+
+	res, err := builder.Table("posts").Where("id", clause.OperatorEqual, 3).Restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		t.Errorf("Expected %d restored row, but got: %d", 1, affected)
+	}
+
+	_, err = builder.Table("posts").Where("id", clause.OperatorEqual, 1).ForceDelete()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = builder.Table("posts").WithTrashed().Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("Expected count with trashed to be %d, but got: %d", 2, count)
+	}
+
+	_, err = builder.Table("users").Restore()
+	if !errors.Is(err, ErrSoftDeleteDisabled) {
+		t.Errorf("Expected ErrSoftDeleteDisabled, got: %v", err)
+	}
+}
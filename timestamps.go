@@ -0,0 +1,108 @@
+package sqlbuilder
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	DefaultCreatedAtColumn = "created_at"
+	DefaultUpdatedAtColumn = "updated_at"
+)
+
+// WithTimestamps makes Insert, InsertMany and Update fill the created_at and updated_at columns of the given tables.
+func WithTimestamps(tables ...string) Option {
+	return func(s *SQLBuilder) {
+		s.timestampTables = append(s.timestampTables, tables...)
+	}
+}
+
+// WithTimestampColumns renames the automatic timestamp columns, an empty name disables that column.
+func WithTimestampColumns(createdAt string, updatedAt string) Option {
+	return func(s *SQLBuilder) {
+		s.createdAtColumn = createdAt
+		s.updatedAtColumn = updatedAt
+	}
+}
+
+// WithClock replaces time.Now as the source of automatic timestamps, mostly useful in tests.
+func WithClock(clock func() time.Time) Option {
+	return func(s *SQLBuilder) {
+		if clock != nil {
+			s.clock = clock
+		}
+	}
+}
+
+// WithUTC stores automatic timestamps in UTC instead of local time.
+func WithUTC(enabled bool) Option {
+	return func(s *SQLBuilder) {
+		s.utc = enabled
+	}
+}
+
+// WithoutTimestamps skips the automatic timestamps for the current statement.
+func (s *SQLBuilder) WithoutTimestamps() *SQLBuilder {
+	s.withoutTimestamps = true
+	return s
+}
+
+func (s *SQLBuilder) now() time.Time {
+	now := time.Now()
+	if s.clock != nil {
+		now = s.clock()
+	}
+
+	if s.utc {
+		return now.UTC()
+	}
+
+	return now
+}
+
+func (s *SQLBuilder) usesTimestamps() bool {
+	return !s.withoutTimestamps && slices.Contains(s.timestampTables, s.table)
+}
+
+// timestampRows sets the timestamp columns the caller did not set on copies of the inserted rows.
+func (s *SQLBuilder) timestampRows(rows []map[string]any) []map[string]any {
+	if !s.usesTimestamps() {
+		return rows
+	}
+
+	now := s.now()
+	stamped := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		r := make(map[string]any, len(row)+2)
+		for k, v := range row {
+			r[k] = v
+		}
+		for _, column := range []string{s.createdAtColumn, s.updatedAtColumn} {
+			if _, ok := r[column]; !ok && column != "" {
+				r[column] = now
+			}
+		}
+		stamped = append(stamped, r)
+	}
+
+	return stamped
+}
+
+// timestampUpdate sets the updated_at column, unless the caller did, on a copy of the updated values.
+func (s *SQLBuilder) timestampUpdate(data map[string]any) map[string]any {
+	if !s.usesTimestamps() || s.updatedAtColumn == "" {
+		return data
+	}
+
+	if _, ok := data[s.updatedAtColumn]; ok {
+		return data
+	}
+
+	stamped := make(map[string]any, len(data)+1)
+	for k, v := range data {
+		stamped[k] = v
+	}
+	stamped[s.updatedAtColumn] = s.now()
+
+	return stamped
+}
@@ -0,0 +1,71 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+func TestExecuteTimestamps(t *testing.T) {
+	dba, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dba.Exec(`
+		CREATE TABLE articles(
+			id integer primary key,
+			title TEXT,
+			created_at datetime,
+			updated_at datetime
+		)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone("WITA", 8*60*60))
+	builder := New(dialect.New("?", "`", "`"), dba, WithTimestamps("articles"), WithClock(func() time.Time { return now }), WithUTC(true))
+
+	_, err = builder.Table("articles").InsertMany([]map[string]any{
+		{"title": "first"},
+		{"title": "second", "created_at": now.Add(-time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "INSERT INTO `articles`(`created_at`,`title`,`updated_at`) VALUES(?,?,?),(?,?,?)" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if created := builder.GetArguments()[0].(time.Time); !created.Equal(now) || created.Location() != time.UTC {
+		t.Errorf("Expected created_at to be %v in UTC, but got: %v", now, created)
+	}
+
+	data := map[string]any{"title": "updated"}
+	_, err = builder.Table("articles").Where("id", clause.OperatorEqual, 1).Update(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "UPDATE `articles` SET `title` = ?, `updated_at` = ? WHERE `id` = ?" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+
+	if len(data) != 1 {
+		t.Errorf("Expected the caller's map to be left untouched, got: %v", data)
+	}
+
+	_, err = builder.Table("articles").WithoutTimestamps().Where("id", clause.OperatorEqual, 1).Update(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql := builder.GetSql(); sql != "UPDATE `articles` SET `title` = ? WHERE `id` = ?" {
+		t.Fatalf("Unexpected SQL result, got: %s", sql)
+	}
+}
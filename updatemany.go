@@ -0,0 +1,125 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/suryaherdiyanto/sqlbuilder/clause"
+	"github.com/suryaherdiyanto/sqlbuilder/dialect"
+)
+
+var ErrUpdateManyJoins = errors.New("sqlbuilder: UpdateMany does not support joins")
+var ErrUpdateManyTail = errors.New("sqlbuilder: UpdateMany does not support GROUP BY, ORDER BY, LIMIT or OFFSET")
+
+// UpdateMany updates every row identified by its keyColumn value with the other columns of the row,
+// compiled to UPDATE ... SET column = CASE key WHEN ... END WHERE key IN (...). Rows are split into as
+// many statements as the dialect's parameter limit requires, run them in a transaction to apply all or
+// none. The conditions of the builder restrict the updated rows further. It returns the total number
+// of rows affected. Rows must set a column besides their key, and the builder must not have joins or
+// tail clauses.
+func (s *SQLBuilder) UpdateMany(keyColumn string, rows []map[string]any) (int64, error) {
+	if len(s.joins) > 0 {
+		return 0, ErrUpdateManyJoins
+	}
+
+	if s.tailClauseStatement != "" {
+		return 0, ErrUpdateManyTail
+	}
+
+	for i, row := range rows {
+		if _, ok := row[keyColumn]; !ok {
+			return 0, fmt.Errorf("%w: row %d has no %q key column", ErrColumnMismatch, i, keyColumn)
+		}
+
+		if len(row) == 1 {
+			return 0, fmt.Errorf("%w: row %d has no column besides its %q key", ErrNoColumns, i, keyColumn)
+		}
+	}
+
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	values := slices.Clone(s.Values)
+	leadingValues := s.leadingValues
+
+	var total int64
+	for _, batch := range s.updateBatches(keyColumn, rows) {
+		s.Values = slices.Clone(values)
+		s.leadingValues = leadingValues
+		if err := s.prepareUpdateMany(keyColumn, batch); err != nil {
+			return total, err
+		}
+
+		res, err := s.Exec()
+		if err != nil {
+			return total, err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+	}
+
+	return total, nil
+}
+
+func (s *SQLBuilder) prepareUpdateMany(keyColumn string, rows []map[string]any) error {
+	updateStatement := clause.UpdateCase{
+		Table: s.tempTable,
+		Key:   keyColumn,
+		Rows:  rows,
+		Set:   s.timestampUpdate(map[string]any{}),
+	}
+
+	stmt, update, err := updateStatement.Parse(s.Dialect, len(s.Values)+1)
+	if err != nil {
+		return err
+	}
+
+	if s.Dialect.GetName() == dialect.PostgreSQL {
+		s.Values = append(s.Values, update.Values...)
+	} else {
+		s.Values = append(update.Values, s.Values...)
+		s.leadingValues += len(update.Values)
+	}
+
+	// the where clause is rendered last so that implicit placeholders follow the update values
+	s.rawStatement = stmt
+	s.statement = mutationKind
+	if where := s.whereClause(); where != "" {
+		conditions := strings.TrimPrefix(where, "WHERE ")
+		if strings.Contains(conditions, " "+string(clause.ConjuctionOr)+" ") {
+			conditions = "(" + conditions + ")"
+		}
+		stmt += " AND " + conditions
+	}
+
+	s.rawStatement = stmt + s.returningClause()
+
+	return nil
+}
+
+// updateBatches splits the rows so that each statement stays under the dialect's parameter limit,
+// counting the bound key, twice, and value of every column besides the builder's own values.
+func (s *SQLBuilder) updateBatches(keyColumn string, rows []map[string]any) [][]map[string]any {
+	width := 1
+	for _, row := range rows {
+		width = max(width, 2*len(row)-1)
+	}
+
+	_, _, scoped := s.globalScopeClauses()
+	available := s.Dialect.GetName().MaxParameters() - len(s.Values) - len(scoped) - 1
+	size := max(available/width, 1)
+
+	batches := make([][]map[string]any, 0, len(rows)/size+1)
+	for start := 0; start < len(rows); start += size {
+		batches = append(batches, rows[start:min(start+size, len(rows))])
+	}
+
+	return batches
+}